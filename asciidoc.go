@@ -0,0 +1,121 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/thibran/table/row"
+)
+
+// AsciiDoc renders the model as AsciiDoc table delimited by |===. Like
+// Markdown, the header is a single row with the column names. The title
+// is written as block title, the caption as paragraph below the table.
+// Cells spanning several rows or columns get a span specifier, merged
+// cells are left out. AsciiDoc marks only the last row as footer.
+type AsciiDoc struct{}
+
+// Render the model m.
+func (AsciiDoc) Render(w io.Writer, m *Model) error {
+	var b strings.Builder
+	if m.Title != "" {
+		b.WriteString("." + adocEscape(m.Title) + "\n")
+	}
+	spec := make([]string, len(m.Columns))
+	for j, c := range m.Columns {
+		spec[j] = adocAlign(c.Align)
+	}
+	var opts []string
+	if len(m.Header) > 0 {
+		opts = append(opts, "header")
+	}
+	if len(m.Footer) > 0 {
+		opts = append(opts, "footer")
+	}
+	fmt.Fprintf(&b, "[cols=\"%s\"", strings.Join(spec, ","))
+	if len(opts) > 0 {
+		fmt.Fprintf(&b, ",options=\"%s\"", strings.Join(opts, ","))
+	}
+	b.WriteString("]\n|===\n")
+	if len(m.Header) > 0 {
+		names := make([]string, len(m.Columns))
+		for j, c := range m.Columns {
+			names[j] = "|" + adocEscape(c.Name)
+		}
+		b.WriteString(strings.Join(names, " ") + "\n\n")
+	}
+	for _, cells := range append(m.Body, m.Footer...) {
+		var parts []string
+		for _, c := range cells {
+			if c.Merged {
+				continue
+			}
+			parts = append(parts, adocSpan(c)+"|"+adocText(c))
+		}
+		b.WriteString(strings.Join(parts, " ") + "\n")
+	}
+	b.WriteString("|===\n")
+	if m.Caption != "" {
+		fmt.Fprintf(&b, "\n%s\n", adocEscape(m.Caption))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// adocSpan returns the span specifier of c, e.g. 2+ for two columns,
+// .2+ for two rows and 2.3+ for both.
+func adocSpan(c Cell) string {
+	switch {
+	case c.Cols > 1 && c.Rows > 1:
+		return fmt.Sprintf("%d.%d+", c.Cols, c.Rows)
+	case c.Cols > 1:
+		return fmt.Sprintf("%d+", c.Cols)
+	case c.Rows > 1:
+		return fmt.Sprintf(".%d+", c.Rows)
+	}
+	return ""
+}
+
+// adocText returns the escaped text of c, links are written with the
+// link macro.
+func adocText(c Cell) string {
+	s := adocEscape(c.Text)
+	if c.Link == "" {
+		return s
+	}
+	return fmt.Sprintf("link:%s[%s]", c.Link, strings.Replace(s, "]", `\]`, -1))
+}
+
+func adocAlign(a row.Align) string {
+	switch a {
+	case row.AlignRight:
+		return ">"
+	case row.AlignCenter:
+		return "^"
+	}
+	return "<"
+}
+
+// adocReplacer writes the characters of inline markup as character
+// references, which AsciiDoc passes through without formatting.
+var adocReplacer = strings.NewReplacer(
+	`|`, `\|`,
+	`&`, "&#38;",
+	`*`, "&#42;",
+	`_`, "&#95;",
+	`#`, "&#35;",
+	"`", "&#96;",
+	`^`, "&#94;",
+	`~`, "&#126;",
+	`+`, "&#43;",
+	`{`, "&#123;",
+	`<`, "&#60;",
+	"\r\n", " ",
+	"\n", " ",
+)
+
+// adocEscape escapes the cell separator and the inline markup of AsciiDoc
+// in s and replaces line breaks, which would end the cell.
+func adocEscape(s string) string {
+	return adocReplacer.Replace(s)
+}
@@ -0,0 +1,54 @@
+package table
+
+import "testing"
+
+func TestAsciiDoc(t *testing.T) {
+	ta := newModelTable()
+	ta.Caption = "c"
+	ta.Renderer = AsciiDoc{}
+	exp := `.T
+[cols="<,>,>",options="header,footer"]
+|===
+|Name |Latency p50 |Latency p99
+
+|link:http://a[a] |1 |95
+|Total |1 |95
+|===
+
+c
+`
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestAsciiDoc_spans(t *testing.T) {
+	ta, _ := New(false, [][]string{{"a|b", "x", "z"}, {"1", "y", "w"}, {"2", "3", "4"}}...)
+	ta.Span(0, 0, 2, 2)
+	ta.Span(1, 2, 2, 1)
+	ta.Renderer = AsciiDoc{}
+	exp := `[cols="<,<,<"]
+|===
+2.2+|a\|b |z
+.2+|w
+|2 |3
+|===
+`
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestAdocText(t *testing.T) {
+	if s := adocText(Cell{Text: "[x]", Link: "http://a"}); s != `link:http://a[[x\]]` {
+		t.Errorf("wrong link: %s", s)
+	}
+}
+
+func TestAdocEscape(t *testing.T) {
+	s := adocEscape("*x* _y_ #z# {a} <<b>> a|b")
+	exp := "&#42;x&#42; &#95;y&#95; &#35;z&#35; &#123;a} &#60;&#60;b>> a\\|b"
+	if s != exp {
+		t.Errorf("should be %q but is %q", exp, s)
+	}
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/thibran/table"
+)
+
+// Input formats.
+const (
+	formatAuto = "auto"
+	formatCSV  = "csv"
+	formatTSV  = "tsv"
+	formatJSON = "json"
+	formatXLSX = "xlsx"
+)
+
+var errJSONInput = errors.New("json input must be an array of objects or arrays")
+
+// detect returns the format of the input named name starting with head.
+// The file extension wins, otherwise XLSX is detected by the signature of
+// zip archives, JSON by its first rune and TSV by a first line with more
+// tabs than commas.
+func detect(name string, head []byte) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return formatCSV
+	case ".tsv", ".tab":
+		return formatTSV
+	case ".json":
+		return formatJSON
+	case ".xlsx":
+		return formatXLSX
+	}
+	if bytes.HasPrefix(head, []byte("PK\x03\x04")) {
+		return formatXLSX
+	}
+	b := bytes.TrimSpace(head)
+	if len(b) > 0 && (b[0] == '[' || b[0] == '{') {
+		return formatJSON
+	}
+	line := b
+	if i := bytes.IndexByte(b, '\n'); i >= 0 {
+		line = b[:i]
+	}
+	if bytes.Count(line, []byte("\t")) > bytes.Count(line, []byte(",")) {
+		return formatTSV
+	}
+	return formatCSV
+}
+
+// readRows reads all rows of r in the given format. For JSON objects the
+// first row is the header made of the keys in the order they appear, for
+// XLSX the rows of the first sheet are read.
+func readRows(r io.Reader, name, format string) ([][]string, error) {
+	br := bufio.NewReader(r)
+	if format == formatAuto {
+		head, _ := br.Peek(4096)
+		format = detect(name, head)
+	}
+	switch format {
+	case formatCSV:
+		cr := csv.NewReader(br)
+		cr.FieldsPerRecord = -1
+		return cr.ReadAll()
+	case formatTSV:
+		return readTSV(br)
+	case formatJSON:
+		return readJSON(br)
+	case formatXLSX:
+		return readXLSX(br)
+	}
+	return nil, fmt.Errorf("unknown input format %q", format)
+}
+
+// readXLSX reads the texts of the first sheet, the header is set by the
+// -header flag like for the other formats.
+func readXLSX(r io.Reader) ([][]string, error) {
+	t, err := table.ReadXLSX(r, table.WithHeader(0))
+	if err != nil {
+		return nil, err
+	}
+	m, err := t.Model()
+	if err != nil {
+		return nil, err
+	}
+	rows := make([][]string, len(m.Body))
+	for i, cells := range m.Body {
+		rows[i] = make([]string, len(cells))
+		for j, c := range cells {
+			rows[i][j] = c.Text
+		}
+	}
+	return rows, nil
+}
+
+// readTSV reads tab separated lines, quotes have no special meaning.
+func readTSV(r io.Reader) ([][]string, error) {
+	var rows [][]string
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if line != "" {
+			line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+			rows = append(rows, strings.Split(line, "\t"))
+		}
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// readJSON reads an array of objects or an array of arrays. A single
+// object is read as an array with one object.
+func readJSON(r io.Reader) ([][]string, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, err
+	}
+	var arr []json.RawMessage
+	switch first(raw) {
+	case '{':
+		arr = []json.RawMessage{raw}
+	case '[':
+		if err := json.Unmarshal(raw, &arr); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errJSONInput
+	}
+	if len(arr) == 0 {
+		return nil, nil
+	}
+	if first(arr[0]) == '{' {
+		return jsonObjects(arr)
+	}
+	rows := make([][]string, len(arr))
+	for i, e := range arr {
+		var cells []interface{}
+		if err := decode(e, &cells); err != nil {
+			return nil, errJSONInput
+		}
+		for _, c := range cells {
+			rows[i] = append(rows[i], jsonText(c))
+		}
+	}
+	return rows, nil
+}
+
+// jsonObjects returns the keys of the objects in the order of their first
+// appearance as header, followed by one row per object.
+func jsonObjects(arr []json.RawMessage) ([][]string, error) {
+	var keys []string
+	index := make(map[string]int)
+	objs := make([]map[string]string, len(arr))
+	for i, e := range arr {
+		dec := json.NewDecoder(bytes.NewReader(e))
+		dec.UseNumber()
+		if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+			return nil, errJSONInput
+		}
+		objs[i] = make(map[string]string)
+		for dec.More() {
+			t, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			k := t.(string)
+			var v interface{}
+			if err := dec.Decode(&v); err != nil {
+				return nil, err
+			}
+			if _, ok := index[k]; !ok {
+				index[k] = len(keys)
+				keys = append(keys, k)
+			}
+			objs[i][k] = jsonText(v)
+		}
+	}
+	rows := [][]string{keys}
+	for _, obj := range objs {
+		row := make([]string, len(keys))
+		for k, c := range obj {
+			row[index[k]] = c
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func decode(b []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// first returns the first non-space byte of b.
+func first(b []byte) byte {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 {
+		return 0
+	}
+	return b[0]
+}
+
+// jsonText returns the text of a JSON value, nested values are encoded.
+func jsonText(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return fmt.Sprint(v)
+	}
+	b, _ := json.Marshal(v)
+	return string(b)
+}
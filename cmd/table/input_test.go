@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/thibran/table"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name, head, exp string
+	}{
+		{"a.tsv", "a,b", formatTSV},
+		{"a.JSON", "", formatJSON},
+		{"-", " [1]", formatJSON},
+		{"-", "a\tb\tc,d\n", formatTSV},
+		{"-", "a,b\n1\t2\t3", formatCSV},
+		{"a.xlsx", "", formatXLSX},
+		{"-", "PK\x03\x04", formatXLSX},
+	}
+	for _, tt := range tests {
+		if f := detect(tt.name, []byte(tt.head)); f != tt.exp {
+			t.Errorf("%s %q should be %s but is %s", tt.name, tt.head, tt.exp, f)
+		}
+	}
+}
+
+func TestReadRows_tsv(t *testing.T) {
+	rows, err := readRows(strings.NewReader("a\tb\n1\t\"2\n"), "-", formatAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(rows) != `[[a b] [1 "2]]` {
+		t.Errorf("should be %s but is %s", `[[a b] [1 "2]]`, rows)
+	}
+}
+
+func TestReadRows_tsvLongLine(t *testing.T) {
+	long := strings.Repeat("x", 100000)
+	rows, err := readRows(strings.NewReader("a\tb\n"+long+"\t1"), "x.tsv", formatAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[1][0] != long || rows[1][1] != "1" {
+		t.Errorf("the long line should be read whole: %d rows", len(rows))
+	}
+}
+
+func TestReadRows_jsonObjects(t *testing.T) {
+	in := `[{"name": "a", "n": 1.50}, {"x": true, "name": "b", "n": null}]`
+	rows, err := readRows(strings.NewReader(in), "-", formatAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := "[[name n x] [a 1.50 ] [b  true]]"
+	if fmt.Sprint(rows) != exp {
+		t.Errorf("should be %s but is %s", exp, rows)
+	}
+}
+
+func TestReadRows_jsonArrays(t *testing.T) {
+	rows, err := readRows(strings.NewReader(`[["a", 1], ["b", [2]]]`), "-", formatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := "[[a 1] [b [2]]]"
+	if fmt.Sprint(rows) != exp {
+		t.Errorf("should be %s but is %s", exp, rows)
+	}
+}
+
+func TestReadRows_jsonInvalid(t *testing.T) {
+	_, err := readRows(strings.NewReader(`"a"`), "-", formatJSON)
+	if err != errJSONInput {
+		t.Errorf("should be %v but is %v", errJSONInput, err)
+	}
+}
+
+func TestReadRows_xlsx(t *testing.T) {
+	ta, _ := table.New(true, []string{"a", "b"}, []string{"1", "2024-01-02"})
+	ta.Renderer = table.XLSX{}
+	var buf bytes.Buffer
+	if _, err := ta.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := readRows(&buf, "-", formatAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := [][]string{{"a", "b"}, {"1", "2024-01-02"}}
+	if fmt.Sprint(rows) != fmt.Sprint(exp) {
+		t.Errorf("should be %q but is %q", exp, rows)
+	}
+}
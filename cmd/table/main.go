@@ -0,0 +1,411 @@
+// Command table renders CSV, TSV or JSON as a text table.
+//
+//	table [flags] [file...]
+//
+// The files, or stdin if there are none, are read one after another, the
+// input format is detected by file extension or content. Run `table -h`
+// for the flags.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/thibran/table"
+	"github.com/thibran/table/row"
+)
+
+// config holds the command line flags.
+type config struct {
+	in         string
+	header     bool
+	style      string
+	bodyStyle  string
+	widths     string
+	align      string
+	sort       string
+	filter     string
+	maxWidth   int
+	keyColumns int
+	number     bool
+	original   bool
+	title      string
+	format     string
+	crlf       bool
+	columns    string
+}
+
+// errUsage is returned for invalid flags.
+var errUsage = errors.New("usage")
+
+var styles = map[string]func() *table.Style{
+	"square": table.StyleSquare,
+	"boring": table.StyleBoring,
+	"dot":    table.StyleDot,
+	"empty":  table.StyleEmpty,
+}
+
+func main() {
+	err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	if err == errUsage {
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "table:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	var c config
+	fs := flag.NewFlagSet("table", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.StringVar(&c.in, "in", formatAuto, "input format: auto, csv, tsv, json or xlsx (first sheet)")
+	fs.BoolVar(&c.header, "header", true, "treat the first row as header")
+	fs.StringVar(&c.style, "style", "square", "header style: square, boring, dot or empty")
+	fs.StringVar(&c.bodyStyle, "body-style", "empty", "body style: square, boring, dot or empty")
+	fs.StringVar(&c.widths, "widths", "", "comma separated column widths, 0 for the content width")
+	fs.StringVar(&c.align, "align", "", "comma separated column alignments: l, r or c")
+	fs.StringVar(&c.sort, "sort", "", "sort by column n (1-based), -n sorts descending")
+	fs.StringVar(&c.filter, "filter", "", "keep rows containing text, or n=text for column n only")
+	fs.IntVar(&c.maxWidth, "max-width", 0, "split tables wider than this into column chunks")
+	fs.IntVar(&c.keyColumns, "key-columns", 1, "columns repeated in every column chunk, if the table has more columns")
+	fs.BoolVar(&c.number, "number", false, "add a column with row numbers")
+	fs.BoolVar(&c.original, "original", false, "number the rows by their position in the input, before -sort and -filter")
+	fs.StringVar(&c.title, "title", "", "title above the table")
+	fs.StringVar(&c.format, "o", "table", "output format: table, markdown, html, csv, tsv, json, yaml, latex, rst, rst-simple, asciidoc, org, jira, svg, xlsx or ods")
+	fs.StringVar(&c.columns, "columns", "", "comma separated header names or numbers (1-based) of the shown columns")
+	fs.BoolVar(&c.crlf, "crlf", false, "end csv and tsv rows with \\r\\n")
+	if err := fs.Parse(args); err != nil {
+		return errUsage // already printed by fs
+	}
+	rows, err := readAll(fs.Args(), stdin, c.in, c.header)
+	if err != nil {
+		return err
+	}
+	head := 0
+	if c.header && len(rows) > 0 {
+		head = 1
+	}
+	rows, source, err := filter(rows, head, c.filter)
+	if err != nil {
+		return err
+	}
+	if err = sortRows(rows[head:], source, c.sort); err != nil {
+		return err
+	}
+	if rows, err = project(rows, head, c.columns); err != nil {
+		return err
+	}
+	return write(stdout, rows, head, source, c)
+}
+
+// readAll reads the rows of all files, "-" or no files at all is stdin.
+// If header is true, the header of every file after the first is dropped.
+func readAll(files []string, stdin io.Reader, format string, header bool) ([][]string, error) {
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+	var rows [][]string
+	for _, name := range files {
+		b, err := readFile(name, stdin, format)
+		if err != nil {
+			return nil, err
+		}
+		if header && len(rows) > 0 && len(b) > 0 {
+			b = b[1:]
+		}
+		rows = append(rows, b...)
+	}
+	return rows, nil
+}
+
+// readFile reads the rows of the file name, "-" is stdin.
+func readFile(name string, stdin io.Reader, format string) ([][]string, error) {
+	r := stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+	rows, err := readRows(r, name, format)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return rows, nil
+}
+
+// filter returns the header rows and the body rows containing the text of
+// f, ignoring case, and the index of every returned body row among the
+// body rows. If f starts with a column number and "=", only that column
+// is searched.
+func filter(rows [][]string, head int, f string) ([][]string, []int, error) {
+	var source []int
+	if f == "" {
+		for i := head; i < len(rows); i++ {
+			source = append(source, i-head)
+		}
+		return rows, source, nil
+	}
+	col := -1
+	if i := strings.Index(f, "="); i > 0 {
+		if n, err := strconv.Atoi(f[:i]); err == nil {
+			if n < 1 {
+				return nil, nil, fmt.Errorf("invalid filter column %d", n)
+			}
+			col, f = n-1, f[i+1:]
+		}
+	}
+	f = strings.ToLower(f)
+	out := rows[:head:head]
+	for i, r := range rows[head:] {
+		for j, cell := range r {
+			if (col < 0 || j == col) && strings.Contains(strings.ToLower(cell), f) {
+				out = append(out, r)
+				source = append(source, i)
+				break
+			}
+		}
+	}
+	return out, source, nil
+}
+
+// project returns the rows with only the columns of spec, given by
+// header name or 1-based number.
+func project(rows [][]string, head int, spec string) ([][]string, error) {
+	if spec == "" {
+		return rows, nil
+	}
+	var cols []int
+	for _, f := range strings.Split(spec, ",") {
+		j := column(rows, head, strings.TrimSpace(f))
+		if j < 0 {
+			return nil, fmt.Errorf("unknown column %q", f)
+		}
+		cols = append(cols, j)
+	}
+	out := make([][]string, len(rows))
+	for i, r := range rows {
+		out[i] = make([]string, len(cols))
+		for k, j := range cols {
+			if j < len(r) {
+				out[i][k] = r[j]
+			}
+		}
+	}
+	return out, nil
+}
+
+// column returns the index of the column with the header name or the
+// 1-based number f, -1 if there is none.
+func column(rows [][]string, head int, f string) int {
+	if head > 0 {
+		for j, name := range rows[0] {
+			if name == f {
+				return j
+			}
+		}
+	}
+	if n, err := strconv.Atoi(f); err == nil && n > 0 {
+		return n - 1
+	}
+	return -1
+}
+
+// sortRows sorts by the column given as 1-based number, a leading minus
+// sorts descending. Numbers are compared by value. The source index of
+// every row is sorted with the rows.
+func sortRows(rows [][]string, source []int, s string) error {
+	if s == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n == 0 {
+		return fmt.Errorf("invalid sort column %q", s)
+	}
+	desc := n < 0
+	if desc {
+		n = -n
+	}
+	j := n - 1
+	cell := func(i int) string {
+		if j < len(rows[i]) {
+			return rows[i][j]
+		}
+		return ""
+	}
+	order := make([]int, len(rows))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		a, b = order[a], order[b]
+		if desc {
+			a, b = b, a
+		}
+		x, errX := strconv.ParseFloat(strings.TrimSpace(cell(a)), 64)
+		y, errY := strconv.ParseFloat(strings.TrimSpace(cell(b)), 64)
+		if errX == nil && errY == nil {
+			return x < y
+		}
+		return cell(a) < cell(b)
+	})
+	sorted := make([][]string, len(rows))
+	src := make([]int, len(rows))
+	for i, k := range order {
+		sorted[i], src[i] = rows[k], source[k]
+	}
+	copy(rows, sorted)
+	copy(source, src)
+	return nil
+}
+
+var renderers = map[string]table.Renderer{
+	"table":      nil,
+	"markdown":   table.Markdown{},
+	"html":       table.HTML{},
+	"json":       table.JSON{},
+	"yaml":       table.YAML{},
+	"latex":      table.LaTeX{Booktabs: true},
+	"rst":        table.RST{},
+	"rst-simple": table.RST{Simple: true},
+	"asciidoc":   table.AsciiDoc{},
+	"org":        table.Org{},
+	"jira":       table.Jira{},
+	"svg":        table.SVG{Background: "white"},
+	"xlsx":       table.XLSX{},
+	"ods":        table.ODS{},
+	"csv":        table.CSV{},
+	"tsv":        table.TSV,
+}
+
+// write the rows, source is the index of every body row in the input.
+func write(w io.Writer, rows [][]string, head int, source []int, c config) error {
+	r, ok := renderers[c.format]
+	if !ok {
+		return fmt.Errorf("unknown output format %q", c.format)
+	}
+	if csv, ok := r.(table.CSV); ok {
+		csv.UseCRLF = c.crlf
+		r = csv
+	}
+	return writeTable(w, rows, head, source, c, r)
+}
+
+func writeTable(w io.Writer, rows [][]string, head int, source []int, c config,
+	r table.Renderer) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	widths, err := ints(c.widths)
+	if err != nil {
+		return err
+	}
+	aligns, err := alignments(c.align)
+	if err != nil {
+		return err
+	}
+	headStyle, err := style(c.style)
+	if err != nil {
+		return err
+	}
+	bodyStyle, err := style(c.bodyStyle)
+	if err != nil {
+		return err
+	}
+	opts := []table.Option{
+		table.WithHeader(head),
+		table.WithStyle(headStyle, bodyStyle),
+		table.WithWidths(widths...),
+		table.WithAlignment(aligns...),
+		table.WithTitle(c.title, ""),
+	}
+	if c.maxWidth > 0 {
+		keys := c.keyColumns
+		if keys >= maxColumns(rows) {
+			keys = 0
+		}
+		opts = append(opts, table.WithMaxWidth(c.maxWidth, keys))
+	}
+	if c.number {
+		opts = append(opts, table.WithNumbering(1))
+	}
+	ta, err := table.NewWith(rows, opts...)
+	if err != nil {
+		return err
+	}
+	if c.number && c.original {
+		ta.Numbering.Original = true
+		ta.Numbering.Source = source
+	}
+	ta.Renderer = r
+	if _, err = ta.WriteTo(w); err != nil || r != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// maxColumns returns the number of cells of the longest row.
+func maxColumns(rows [][]string) int {
+	n := 0
+	for _, r := range rows {
+		if len(r) > n {
+			n = len(r)
+		}
+	}
+	return n
+}
+
+func style(name string) (*table.Style, error) {
+	s, ok := styles[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown style %q", name)
+	}
+	return s(), nil
+}
+
+// alignments parses comma separated alignments: l, r or c.
+func alignments(s string) ([]row.Align, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var a []row.Align
+	for _, f := range strings.Split(s, ",") {
+		switch strings.TrimSpace(f) {
+		case "l":
+			a = append(a, row.AlignLeft)
+		case "r":
+			a = append(a, row.AlignRight)
+		case "c":
+			a = append(a, row.AlignCenter)
+		default:
+			return nil, fmt.Errorf("invalid alignment %q", f)
+		}
+	}
+	return a, nil
+}
+
+func ints(s string) ([]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var a []int
+	for _, f := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(f))
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid width %q", f)
+		}
+		a = append(a, n)
+	}
+	return a, nil
+}
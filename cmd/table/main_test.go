@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const fruits = "Fruit,Count\nApple,4\nBanana,25\nCherry,100\n"
+
+func runTest(t *testing.T, in string, args ...string) string {
+	var out, errOut bytes.Buffer
+	if err := run(args, strings.NewReader(in), &out, &errOut); err != nil {
+		t.Fatal(err, errOut.String())
+	}
+	return out.String()
+}
+
+func TestRun(t *testing.T) {
+	s := runTest(t, fruits)
+	exp := "Fruit  Count \n=============\nApple  4     \nBanana 25    \nCherry 100   \n"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestRun_sortFilter(t *testing.T) {
+	s := runTest(t, fruits, "-sort", "-2", "-filter", "A", "-header=true", "-style", "empty")
+	exp := "Fruit  Count \nBanana 25    \nApple  4     \n"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestRun_numberOriginal(t *testing.T) {
+	s := runTest(t, fruits, "-sort", "-2", "-filter", "A", "-number", "-original", "-style", "empty")
+	exp := "# Fruit  Count \n2 Banana 25    \n1 Apple  4     \n"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+	s = runTest(t, fruits, "-sort", "-2", "-number", "-style", "empty")
+	exp = "# Fruit  Count \n1 Cherry 100   \n2 Banana 25    \n3 Apple  4     \n"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestRun_files(t *testing.T) {
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.csv"), filepath.Join(dir, "b.csv")
+	if err := os.WriteFile(a, []byte("Fruit,Count\nApple,4\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(b, []byte("Fruit,Count\nBanana,25\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	s := runTest(t, "", "-style", "empty", a, b)
+	exp := "Fruit  Count \nApple  4     \nBanana 25    \n"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+	s = runTest(t, "", "-style", "empty", "-header=false", a, b)
+	if strings.Count(s, "Fruit") != 2 {
+		t.Errorf("without header every row should be kept:\n%s", s)
+	}
+}
+
+func TestRun_maxWidthOneColumn(t *testing.T) {
+	s := runTest(t, "Fruit\nApple\n", "-max-width", "4")
+	exp := "Fruit \n======\nApple \n"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestRun_widthsAlign(t *testing.T) {
+	s := runTest(t, fruits, "-widths", "4,0", "-align", "l,r", "-header=false")
+	exp := "F... Count \nA...     4 \nB...    25 \nC...   100 \n"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestRun_json(t *testing.T) {
+	s := runTest(t, fruits, "-o", "json", "-sort", "1")
+	exp := "[\n  {\"Fruit\": \"Apple\", \"Count\": 4},\n" +
+		"  {\"Fruit\": \"Banana\", \"Count\": 25},\n" +
+		"  {\"Fruit\": \"Cherry\", \"Count\": 100}\n]\n"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestRun_markdown(t *testing.T) {
+	s := runTest(t, fruits, "-o", "markdown", "-align", "l,r", "-filter", "Apple")
+	exp := "| Fruit | Count |\n| ----- | ----: |\n| Apple |     4 |\n"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestRun_tsv(t *testing.T) {
+	s := runTest(t, `[{"a": "x y", "b": 1}]`, "-o", "tsv")
+	exp := "a\tb\nx y\t1\n"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestRun_errors(t *testing.T) {
+	tests := [][]string{
+		{"-style", "nope"},
+		{"-o", "xml"},
+		{"-sort", "x"},
+		{"-widths", "-1"},
+		{"-align", "x"},
+		{"-unknown"},
+		{"-columns", "Nope"},
+	}
+	for _, args := range tests {
+		var out, errOut bytes.Buffer
+		if err := run(args, strings.NewReader(fruits), &out, &errOut); err == nil {
+			t.Errorf("%v should fail", args)
+		}
+	}
+}
+
+func TestRun_csv(t *testing.T) {
+	s := runTest(t, "a\tb\n\"x\t1\n", "-o", "csv", "-crlf")
+	exp := "a,b\r\n\"\"\"x\",1\r\n"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestRun_yamlColumns(t *testing.T) {
+	s := runTest(t, fruits, "-o", "yaml", "-columns", "Count,1", "-filter", "Cherry")
+	exp := "- Count: 100\n  Fruit: Cherry\n"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestRun_rst(t *testing.T) {
+	s := runTest(t, fruits, "-o", "rst-simple", "-filter", "Apple")
+	exp := "=====  =====\nFruit  Count\n=====  =====\nApple  4\n=====  =====\n"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestRun_jira(t *testing.T) {
+	s := runTest(t, fruits, "-o", "jira", "-filter", "Apple")
+	exp := "||Fruit||Count||\n|Apple|4|\n"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestRun_xlsx(t *testing.T) {
+	s := runTest(t, fruits, "-o", "xlsx")
+	if !strings.HasPrefix(s, "PK") {
+		t.Errorf("output should be a zip archive: %q", s[:10])
+	}
+}
@@ -0,0 +1,44 @@
+package table
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// CSV renders the rows of the model as CSV. Fields are quoted if needed,
+// merged cells are empty. The zero value writes the header and body rows
+// separated by commas, each ending with "\n".
+type CSV struct {
+	Comma   rune // field delimiter, e.g. '\t' for TSV, ',' if 0
+	UseCRLF bool // if true, rows end with "\r\n"
+	Footer  bool // if true, the footer rows are written as well
+}
+
+// TSV renders the rows of the model separated by tabs.
+var TSV = CSV{Comma: '\t'}
+
+// Render the model m.
+func (c CSV) Render(w io.Writer, m *Model) error {
+	cw := csv.NewWriter(w)
+	if c.Comma != 0 {
+		cw.Comma = c.Comma
+	}
+	cw.UseCRLF = c.UseCRLF
+	rows := append(m.Header[:len(m.Header):len(m.Header)], m.Body...)
+	if c.Footer {
+		rows = append(rows, m.Footer...)
+	}
+	for _, cells := range rows {
+		r := make([]string, len(cells))
+		for j, cell := range cells {
+			if !cell.Merged {
+				r[j] = cell.Text
+			}
+		}
+		if err := cw.Write(r); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
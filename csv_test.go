@@ -0,0 +1,50 @@
+package table
+
+import (
+	"io"
+	"testing"
+)
+
+func TestCSV(t *testing.T) {
+	ta := newModelTable()
+	ta.Renderer = CSV{}
+	exp := "Name,Latency,\n,p50,p99\na,1,95\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestCSV_footer(t *testing.T) {
+	ta := newModelTable()
+	ta.Renderer = CSV{Comma: ';', UseCRLF: true, Footer: true}
+	exp := "Name;Latency;\r\n;p50;p99\r\na;1;95\r\nTotal;1;95\r\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestCSV_quote(t *testing.T) {
+	ta, _ := New(false, [][]string{{`a"b`, "c\nd", "e,f", " g"}}...)
+	ta.Renderer = CSV{}
+	exp := "\"a\"\"b\",\"c\nd\",\"e,f\",\" g\"\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestTSV(t *testing.T) {
+	ta, _ := New(true, [][]string{{"a", "b c"}, {"1", "x\ty"}}...)
+	ta.Renderer = TSV
+	exp := "a\tb c\n1\t\"x\ty\"\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestCSV_invalidComma(t *testing.T) {
+	ta, _ := New(false, [][]string{{"a"}}...)
+	ta.Renderer = CSV{Comma: '"'}
+	if _, err := ta.WriteTo(io.Discard); err == nil {
+		t.Errorf("quote should be an invalid delimiter")
+	}
+}
@@ -0,0 +1,12 @@
+// Package table converts [][]string or an io.Reader with CSV to a text
+// table.
+//
+//	ta, _ := table.New(true, [][]string{
+//		{"Fruits:", "Count:"},
+//		{"Apple", "4"},
+//		{"Banana", "25"}}...)
+//	fmt.Println(ta)
+//
+// The look of the header and the body is set with a Style, either a
+// preset like StyleSquare or one created with NewStyle.
+package table
@@ -2,6 +2,7 @@ package draw
 
 import (
 	"io"
+	"strings"
 	"unicode/utf8"
 
 	"github.com/thibran/table/row"
@@ -13,9 +14,10 @@ type Drawer struct {
 	LineHeadBot bool
 	LineHeadV   bool
 
-	LineBodyTop bool
-	LineBodyBot bool
-	LineBodyV   bool
+	LineBodyTop   bool
+	LineBodyBot   bool
+	LineBodyV     bool
+	LineBodyEvery int // if > 0, the body top line is only drawn every n body rows
 
 	HeadEdge  rune
 	HeadLineH rune
@@ -25,13 +27,28 @@ type Drawer struct {
 	BodyLineH    rune
 	BodyLineV    rune
 	ColumnCap    row.ColumnCap
+	HeadRows     int         // number of header rows, lines are drawn between them
+	HeadCols     int         // number of leading columns drawn with head vertical lines
+	Spans        row.Spans   // merged cells, Span.Row is the index of the drawn row
+	Title        string      // centered above the table
+	Caption      string      // left aligned below the table
+	FrameText    bool        // if true, Title and Caption are enclosed in the border
+	RowPaint     row.Paint   // paint of the row drawn next, set before calling Row
+	CellPaint    []row.Paint // paint of the cells of the row drawn next
+	CellLink     []string    // link targets of the cells of the row drawn next
+	Indent       int         // whitespace in front of every line
+	Gap          int         // whitespace between columns
 	BytesWritten int64
 	Err          error
 	io.Writer
+	n        int  // index of the row drawn next
+	bodyRows int  // number of drawn body rows
+	indented bool // true if the current line is indented
 }
 
 // Row writes a single row into the io.Writer.
 func (d *Drawer) Row(r row.Row, isHeader, firstBodyRow bool) {
+	defer func() { d.n++ }()
 	if isHeader {
 		d.head(r)
 		return
@@ -42,64 +59,167 @@ func (d *Drawer) Row(r row.Row, isHeader, firstBodyRow bool) {
 	return
 }
 
+// RowCap returns the ColumnCap of the row drawn next. The top-left cell
+// of a span gets the width of all columns it covers.
+func (d *Drawer) RowCap() row.ColumnCap {
+	c := make(row.ColumnCap, len(d.ColumnCap))
+	copy(c, d.ColumnCap)
+	for _, s := range d.Spans {
+		if s.Row == d.n && s.Col < len(c) {
+			c[s.Col] = d.spanWidth(s.Col, s.Cols)
+		}
+	}
+	return c
+}
+
 func (d *Drawer) head(r row.Row) {
-	edge := d.edgeRune(false)
-	hline := d.hlineRune(false)
-	vLine := d.isLineV(false)
-	opositVlineTrue := d.isOpositVlineTrue(false)
-	writeEdge := func() {
-		d.writeEdge(vLine, opositVlineTrue, edge, hline)
-	}
-	// top line
-	if d.LineHeadTop {
-		d.lineH(hline, writeEdge)
+	switch {
+	case d.n == 0 && d.isFramedTitle(): // title already drew the top line
+	case d.n == 0 && d.LineHeadTop: // top line
+		d.gridLine(false, -1, d.n)
+		d.writeRune('\n')
+	case d.n > 0 && d.LineHeadBot: // line between header rows
+		d.gridLine(false, d.n-1, d.n)
 		d.writeRune('\n')
 	}
 	// header
 	d.writeRow(r, false)
-	// bottom line
-	if d.LineHeadBot {
+	// bottom line, only below the last header row
+	if d.LineHeadBot && d.n+1 >= d.HeadRows {
 		d.writeRune('\n')
-		d.lineH(hline, writeEdge)
+		d.gridLine(false, d.n, d.n+1)
 	}
 }
 
 func (d *Drawer) body(r row.Row, firstBodyRow bool) {
-	edge := d.edgeRune(true)
-	hline := d.hlineRune(true)
-	vLine := d.isLineV(true)
-	opositVlineTrue := d.isOpositVlineTrue(true)
-	writeEdge := func() {
-		d.writeEdge(vLine, opositVlineTrue, edge, hline)
-	}
 	writeHline := func() {
-		d.lineH(hline, writeEdge)
+		d.gridLine(true, d.n-1, d.n)
 	}
 	d.bodyRow(r, firstBodyRow, writeHline)
+	d.bodyRows++
 }
 
 func (d *Drawer) WriteBottomBodyLine() {
-	edge := d.edgeRune(true)
-	hline := d.hlineRune(true)
-	vLine := d.isLineV(true)
-	opositVlineTrue := d.isOpositVlineTrue(true)
-	writeEdge := func() {
-		d.writeEdge(vLine, opositVlineTrue, edge, hline)
-	}
 	if d.LineBodyBot {
 		d.writeRune('\n')
-		d.lineH(hline, writeEdge)
+		d.gridLine(true, d.n-1, -1)
+	}
+}
+
+// WriteTitle writes the Title above the first row, if any.
+func (d *Drawer) WriteTitle() {
+	if d.Title == "" {
+		return
+	}
+	if d.FrameText {
+		d.gridLine(false, -1, -1)
+		d.writeRune('\n')
+	}
+	d.writeText(d.Title, true, d.FrameText)
+	d.writeRune('\n')
+	if d.FrameText {
+		d.gridLine(false, -1, 0)
+		d.writeRune('\n')
+	}
+}
+
+// WriteCaption writes the Caption below the last row, if any.
+func (d *Drawer) WriteCaption() {
+	if d.Caption == "" {
+		return
+	}
+	d.writeRune('\n')
+	if d.FrameText && !d.LineBodyBot {
+		d.gridLine(false, d.n-1, -1)
+		d.writeRune('\n')
+	}
+	d.writeText(d.Caption, false, d.FrameText)
+	if d.FrameText {
+		d.writeRune('\n')
+		d.gridLine(false, -1, -1)
 	}
 }
 
+// WriteFooter writes s centered below the table, e.g. a page number.
+// A footer wider than the table is not wrapped.
+func (d *Drawer) WriteFooter(s string) {
+	if s == "" {
+		return
+	}
+	d.writeRune('\n')
+	width := d.spanWidth(0, len(d.ColumnCap)) + 2*d.sepWidth()
+	if utf8.RuneCountInString(s) >= width {
+		d.writeString(s)
+		return
+	}
+	d.writeText(s, true, false)
+}
+
+// WriteBreak writes a line break followed by s, e.g. between two pages.
+// The body rows after the break are counted anew for LineBodyEvery.
+func (d *Drawer) WriteBreak(s string) {
+	d.bodyRows = 0
+	d.writeRune('\n')
+	d.addBytesWritten(io.WriteString(d, s))
+	if strings.HasSuffix(s, "\n") {
+		d.indented = false
+	}
+}
+
+// Width returns the number of runes of a drawn line, including Indent.
+func (d *Drawer) Width() int {
+	return d.Indent + d.spanWidth(0, len(d.ColumnCap)) + 2*d.sepWidth()
+}
+
+// SetRow sets the index of the row drawn next, e.g. to draw the header
+// rows again on a new page.
+func (d *Drawer) SetRow(i int) {
+	d.n = i
+}
+
+// writeText wraps s to the width of the table. If frame is true,
+// the text is enclosed by the vertical lines of the head.
+func (d *Drawer) writeText(s string, center, frame bool) {
+	width := d.spanWidth(0, len(d.ColumnCap))
+	if !frame {
+		width += 2 * d.sepWidth()
+	}
+	for i, line := range row.Wrap(s, width) {
+		if i > 0 {
+			d.writeRune('\n')
+		}
+		if center {
+			n := (width - utf8.RuneCountInString(line)) / 2
+			line = strings.Repeat(" ", n) + line
+		}
+		if frame {
+			d.lineV(false)
+		}
+		d.writeString(row.TrimTextToMaxLength(line, width))
+		if frame {
+			d.lineV(false)
+		}
+	}
+}
+
+// isFramedTitle returns true, if the title drew a line above the first row.
+func (d *Drawer) isFramedTitle() bool {
+	return d.Title != "" && d.FrameText
+}
+
 func (d *Drawer) WriteNewline() {
 	d.writeRune('\n')
 }
 
 func (d *Drawer) bodyRow(r row.Row, firstRow bool, writeHline func()) {
 	// dont print a topline if there is already one
-	printFirstRow := firstRow && !d.LineHeadBot && d.LineBodyTop
+	printFirstRow := firstRow && !d.LineHeadBot && d.LineBodyTop &&
+		!(d.n == 0 && d.isFramedTitle())
 	printNonFirstRow := !firstRow && d.LineBodyTop
+	if d.LineBodyEvery > 0 {
+		printFirstRow = false
+		printNonFirstRow = printNonFirstRow && d.bodyRows%d.LineBodyEvery == 0
+	}
 	if printFirstRow || printNonFirstRow {
 		writeHline()
 		d.writeRune('\n')
@@ -107,13 +227,149 @@ func (d *Drawer) bodyRow(r row.Row, firstRow bool, writeHline func()) {
 	d.writeRow(r, true)
 }
 
+// writeRow draws one cell per column, missing cells are left empty.
+// A merged cell is drawn over all its columns, rows covered by a span
+// below its top-left cell are left empty as well.
+// The RowPaint is applied to the whole row, the CellPaint and CellLink
+// to single cells.
 func (d *Drawer) writeRow(r row.Row, isBody bool) {
-	d.lineV(isBody)
-	for i, cell := range r {
-		cell = row.TrimTextToMaxLength(cell, d.ColumnCap[i])
+	d.writeString(d.RowPaint.Start())
+	d.lineV(d.isBodyLineV(isBody, 0))
+	for j := 0; j < len(d.ColumnCap); {
+		var cell string
+		cols := 1
+		s, ok := d.Spans.At(d.n, j)
+		if ok {
+			cols = s.Cols
+		}
+		if (!ok || s.Row == d.n) && j < len(r) {
+			cell = r[j]
+		}
+		cell = row.TrimTextToMaxLength(cell, d.spanWidth(j, cols))
+		if j < len(d.CellLink) {
+			cell = row.Hyperlink(cell, d.CellLink[j])
+		}
+		if j < len(d.CellPaint) && d.CellPaint[j] != "" {
+			cell = d.CellPaint[j].Wrap(cell) + d.RowPaint.Start()
+		}
 		d.writeString(cell)
-		d.lineV(isBody)
+		j += cols
+		if j < len(d.ColumnCap) {
+			d.writeGap(' ')
+		}
+		d.lineV(d.isBodyLineV(isBody, j))
+	}
+	if d.RowPaint != "" {
+		d.writeString(row.Reset)
+	}
+}
+
+// isBodyLineV returns true, if the vertical line left of column b is drawn
+// with the body runes. Lines next to the leading head columns are not.
+func (d *Drawer) isBodyLineV(isBody bool, b int) bool {
+	return isBody && (d.HeadCols == 0 || b > d.HeadCols)
+}
+
+// gridLine draws a horizontal line between the rows above and below.
+// A row index of -1 stands for no row, e.g. above the first row.
+// Columns merged over both rows are left empty and edges are only
+// drawn where a vertical line meets the horizontal line.
+func (d *Drawer) gridLine(isBody bool, above, below int) {
+	hline := d.hlineRune(isBody)
+	if len(d.Spans) == 0 && (above >= 0 || below >= 0) {
+		vLine := d.isLineV(isBody)
+		opositVlineTrue := d.isOpositVlineTrue(isBody)
+		edge := d.edgeRune(isBody)
+		d.lineH(hline, func() {
+			d.writeEdge(vLine, opositVlineTrue, edge, hline)
+		})
+		return
+	}
+	cols := len(d.ColumnCap)
+	for b := 0; b <= cols; b++ {
+		left := b > 0 && d.isCrossed(above, below, b-1)
+		right := b < cols && d.isCrossed(above, below, b)
+		split := d.isSplit(above, b) || d.isSplit(below, b)
+		if b > 0 && b < cols {
+			r := hline
+			if !left {
+				r = ' '
+			}
+			d.writeGap(r)
+		}
+		d.junction(isBody, left || right, split)
+		if b == cols {
+			break
+		}
+		r := hline
+		if !right {
+			r = ' '
+		}
+		for i := 0; i < d.ColumnCap[b]; i++ {
+			d.writeRune(r)
+		}
+	}
+}
+
+// isCrossed returns false if column j of both rows is one merged cell.
+func (d *Drawer) isCrossed(above, below, j int) bool {
+	if above < 0 || below < 0 {
+		return true
+	}
+	return !d.Spans.Same(above, j, below, j)
+}
+
+// isSplit returns true if a vertical line is drawn in row i
+// left of column b. The outer lines are always split.
+func (d *Drawer) isSplit(i, b int) bool {
+	if b == 0 || b == len(d.ColumnCap) {
+		return true
 	}
+	return i >= 0 && !d.Spans.Same(i, b-1, i, b)
+}
+
+// junction writes the rune where a horizontal line meets a vertical one.
+func (d *Drawer) junction(isBody, hline, split bool) {
+	vLine := d.isLineV(isBody)
+	switch {
+	case !vLine && !d.isOpositVlineTrue(isBody):
+		return
+	case !hline && split && vLine:
+		d.writeRune(d.vlineRune(isBody))
+	case !hline:
+		d.writeRune(' ')
+	case split && vLine:
+		d.writeRune(d.edgeRune(isBody))
+	default:
+		d.writeRune(d.hlineRune(isBody))
+	}
+}
+
+// spanWidth returns the width of cols columns starting at column j,
+// including the vertical lines between them.
+func (d *Drawer) spanWidth(j, cols int) int {
+	var width int
+	for i := j; i < j+cols && i < len(d.ColumnCap); i++ {
+		if i > j {
+			width += d.ColumnSep()
+		}
+		width += d.ColumnCap[i]
+	}
+	return width
+}
+
+// ColumnSep returns the width between two columns, the vertical line and
+// the gap, which a span covers as well.
+func (d *Drawer) ColumnSep() int {
+	return d.sepWidth() + d.Gap
+}
+
+// sepWidth returns the width of a vertical line, see lineV.
+func (d *Drawer) sepWidth() int {
+	if d.LineHeadV || d.LineBodyV {
+		return 1
+	}
+	return 0
 }
 
 // writeEdge rune if either head or body vline is true (rune differs).
@@ -129,14 +385,24 @@ func (d *Drawer) writeEdge(vLine, opositVlineTrue bool, edge, hline rune) {
 func (d *Drawer) lineH(hline rune, writeEdge func()) {
 	// edge or hline rune, or nothing if vline head & body are false
 	writeEdge()
-	for _, count := range d.ColumnCap {
+	for j, count := range d.ColumnCap {
 		for i := 0; i < count; i++ {
 			d.writeRune(hline)
 		}
+		if j < len(d.ColumnCap)-1 {
+			d.writeGap(hline)
+		}
 		writeEdge()
 	}
 }
 
+// writeGap writes the space between two columns filled with r.
+func (d *Drawer) writeGap(r rune) {
+	for i := 0; i < d.Gap; i++ {
+		d.writeRune(r)
+	}
+}
+
 // lineV prints the vline rune or a space if.
 func (d *Drawer) lineV(isBody bool) {
 	if d.isLineV(isBody) {
@@ -188,16 +454,31 @@ func (d *Drawer) isOpositVlineTrue(isBody bool) bool {
 }
 
 func (d *Drawer) writeString(s string) {
+	d.indent()
 	d.addBytesWritten(io.WriteString(d, s))
 }
 
 func (d *Drawer) writeRune(r rune) {
+	if r == '\n' {
+		d.indented = false
+	} else {
+		d.indent()
+	}
 	n := utf8.RuneLen(r)
 	buf := make([]byte, n)
 	utf8.EncodeRune(buf, r)
 	d.addBytesWritten(d.Write(buf))
 }
 
+// indent writes the Indent whitespace, if the current line has none yet.
+func (d *Drawer) indent() {
+	if d.indented || d.Indent <= 0 {
+		return
+	}
+	d.indented = true
+	d.addBytesWritten(io.WriteString(d, strings.Repeat(" ", d.Indent)))
+}
+
 func (d *Drawer) addBytesWritten(n int, err error) {
 	d.BytesWritten += int64(n)
 	if err != nil {
@@ -568,3 +568,266 @@ func TestWriteAll_headVlineTrue_bodyVlineFalse(t *testing.T) {
 		t.Error(err(exp, s))
 	}
 }
+
+func TestWriteAll_spanTitle(t *testing.T) {
+	d := newBufDrawer()
+	d.LineHeadTop = true
+	d.LineHeadBot = true
+	d.LineHeadV = true
+	d.LineBodyV = true
+	d.HeadEdge = '+'
+	d.HeadLineH = '='
+	d.HeadLineV = '|'
+	d.BodyLineV = '|'
+	d.Spans = row.Spans{{Row: 0, Col: 0, Rows: 1, Cols: 2}}
+	rows := []row.Row{
+		{"title ", ""},
+		{"a1 ", "a2 "},
+	}
+	d.writeAll(rows, true)
+	s := d.String()
+	exp := "+=======+\n|title  |\n+===+===+\n|a1 |a2 |"
+	if s != exp {
+		t.Error(err(exp, s))
+	}
+}
+
+func TestWriteAll_spanRows(t *testing.T) {
+	d := newBufDrawer()
+	d.LineBodyTop = true
+	d.LineBodyBot = true
+	d.LineBodyV = true
+	d.BodyEdge = '+'
+	d.BodyLineH = '-'
+	d.BodyLineV = '|'
+	d.Spans = row.Spans{{Row: 0, Col: 0, Rows: 2, Cols: 1}}
+	rows := []row.Row{
+		{"a1 ", "a2 "},
+		{"b1 ", "b2 "},
+	}
+	d.writeAll(rows, false)
+	s := d.String()
+	exp := "+---+---+\n|a1 |a2 |\n|   +---+\n|   |b2 |\n+---+---+"
+	if s != exp {
+		t.Error(err(exp, s))
+	}
+}
+
+func TestWriteAll_spanNoVline(t *testing.T) {
+	d := newBufDrawer()
+	d.LineHeadBot = true
+	d.HeadLineH = '='
+	d.Spans = row.Spans{{Row: 0, Col: 0, Rows: 1, Cols: 2}}
+	rows := []row.Row{
+		{"group "},
+		{"a1 ", "a2 "},
+	}
+	d.writeAll(rows, true)
+	s := d.String()
+	exp := "group \n======\na1 a2 "
+	if s != exp {
+		t.Error(err(exp, s))
+	}
+}
+
+func TestRowCap_span(t *testing.T) {
+	d := newBufDrawer()
+	d.LineBodyV = true
+	d.Spans = row.Spans{{Row: 0, Col: 0, Rows: 1, Cols: 2}}
+	c := d.RowCap()
+	if c[0] != 7 || c[1] != 3 {
+		t.Errorf("should be [7 3] but is %v", c)
+	}
+}
+
+func TestWriteAll_headRows(t *testing.T) {
+	d := newBufDrawer()
+	d.HeadRows = 2
+	d.LineHeadTop = true
+	d.LineHeadBot = true
+	d.HeadLineH = '='
+	rows := []row.Row{
+		{"g1 ", "g2 "},
+		{"h1 ", "h2 "},
+		{"a1 ", "a2 "},
+	}
+	d.Row(rows[0], true, false)
+	d.WriteNewline()
+	d.Row(rows[1], true, false)
+	d.WriteNewline()
+	d.Row(rows[2], false, true)
+	s := d.String()
+	exp := "======\ng1 g2 \n======\nh1 h2 \n======\na1 a2 "
+	if s != exp {
+		t.Error(err(exp, s))
+	}
+}
+
+func TestWriteTitle(t *testing.T) {
+	d := newBufDrawer()
+	d.Title = "ab"
+	d.WriteTitle()
+	s := d.String()
+	exp := "  ab  \n"
+	if s != exp {
+		t.Error(err(exp, s))
+	}
+}
+
+func TestWriteTitle_frame(t *testing.T) {
+	d := newBufDrawer()
+	d.LineHeadTop = true
+	d.LineHeadV = true
+	d.HeadEdge = '+'
+	d.HeadLineH = '='
+	d.HeadLineV = '|'
+	d.Title = "ab"
+	d.FrameText = true
+	d.WriteTitle()
+	d.Row(row.Row{"h1 ", "h2 "}, true, false)
+	s := d.String()
+	exp := "+=======+\n|  ab   |\n+===+===+\n|h1 |h2 |"
+	if s != exp {
+		t.Error(err(exp, s))
+	}
+}
+
+func TestWriteCaption_frame(t *testing.T) {
+	d := newBufDrawer()
+	d.LineBodyV = true
+	d.HeadEdge = '+'
+	d.HeadLineH = '='
+	d.HeadLineV = '|'
+	d.BodyLineV = '|'
+	d.Caption = "note"
+	d.FrameText = true
+	d.Row(row.Row{"a1 ", "a2 "}, false, true)
+	d.WriteCaption()
+	s := d.String()
+	exp := "|a1 |a2 |\n=========\n note    \n========="
+	if s != exp {
+		t.Error(err(exp, s))
+	}
+}
+
+func TestBodyRow_headCols(t *testing.T) {
+	d := newBufDrawer()
+	d.HeadCols = 1
+	d.LineHeadV = true
+	d.LineBodyV = true
+	d.HeadLineV = 'I'
+	d.BodyLineV = '|'
+	d.bodyRowTest(row.Row{"1 ", "a2 "}, false)
+	s := d.String()
+	exp := "I1  Ia2 |"
+	if s != exp {
+		t.Error(err(exp, s))
+	}
+}
+
+func TestWriteAll_lineBodyEvery(t *testing.T) {
+	d := newBufDrawer()
+	d.LineBodyTop = true
+	d.LineBodyEvery = 2
+	d.BodyLineH = '-'
+	rows := []row.Row{
+		{"a1 ", "a2 "},
+		{"b1 ", "b2 "},
+		{"c1 ", "c2 "},
+	}
+	d.writeAll(rows, false)
+	s := d.String()
+	exp := "a1 a2 \nb1 b2 \n------\nc1 c2 "
+	if s != exp {
+		t.Error(err(exp, s))
+	}
+}
+
+func TestBodyRow_rowPaint(t *testing.T) {
+	d := newBufDrawer()
+	d.RowPaint = row.Red
+	d.bodyRowTest(row.Row{"a1 ", "a2 "}, false)
+	s := d.String()
+	exp := "\x1b[31ma1 a2 \x1b[0m"
+	if s != exp {
+		t.Error(err(exp, s))
+	}
+}
+
+func TestBodyRow_cellPaint(t *testing.T) {
+	d := newBufDrawer()
+	d.RowPaint = row.BgGray
+	d.CellPaint = []row.Paint{"", row.Red}
+	d.bodyRowTest(row.Row{"a1 ", "a2 "}, false)
+	s := d.String()
+	exp := "\x1b[100ma1 \x1b[31ma2 \x1b[0m\x1b[100m\x1b[0m"
+	if s != exp {
+		t.Error(err(exp, s))
+	}
+}
+
+func TestWriteAll_indent(t *testing.T) {
+	d := newBufDrawer()
+	d.Indent = 2
+	d.LineHeadBot = true
+	d.HeadLineH = '='
+	d.Row(row.Row{"h1 ", "h2 "}, true, false)
+	d.WriteNewline()
+	d.Row(row.Row{"a1 ", "a2 "}, false, true)
+	s := d.String()
+	exp := "  h1 h2 \n  ======\n  a1 a2 "
+	if s != exp {
+		t.Error(err(exp, s))
+	}
+	if d.BytesWritten != int64(len(exp)) {
+		t.Errorf("written bytes should be %d but are %d", len(exp), d.BytesWritten)
+	}
+}
+
+func TestWriteAll_gap(t *testing.T) {
+	d := newBufDrawer()
+	d.Gap = 2
+	d.LineHeadBot = true
+	d.LineHeadV = true
+	d.HeadEdge = '+'
+	d.HeadLineH = '='
+	d.HeadLineV = '|'
+	d.Row(row.Row{"h1 ", "h2 "}, true, false)
+	s := d.String()
+	exp := "|h1   |h2 |\n+=====+===+"
+	if s != exp {
+		t.Error(err(exp, s))
+	}
+}
+
+func TestBodyRow_cellLink(t *testing.T) {
+	d := newBufDrawer()
+	d.CellLink = []string{"", "u"}
+	d.bodyRowTest(row.Row{"a1 ", "a2 "}, false)
+	s := d.String()
+	exp := "a1 \x1b]8;;u\x1b\\a2\x1b]8;;\x1b\\ "
+	if s != exp {
+		t.Error(err(exp, s))
+	}
+}
+
+func TestWriteFooter(t *testing.T) {
+	d := newBufDrawer()
+	d.WriteFooter("p1")
+	d.WriteBreak("\f")
+	s := d.String()
+	exp := "\n  p1  \n\f"
+	if s != exp {
+		t.Error(err(exp, s))
+	}
+}
+
+func TestWidth(t *testing.T) {
+	d := newBufDrawer()
+	d.Indent = 2
+	d.Gap = 1
+	d.LineBodyV = true
+	if w := d.Width(); w != 12 {
+		t.Errorf("should be %d but is %d", 12, w)
+	}
+}
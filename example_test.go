@@ -0,0 +1,128 @@
+package table_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thibran/table"
+	"github.com/thibran/table/row"
+)
+
+// printTable prints ta without the whitespace at the end of its lines,
+// which example output can not contain.
+func printTable(ta *table.Table) {
+	lines := strings.Split(ta.String(), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " ")
+	}
+	fmt.Println(strings.Join(lines, "\n"))
+}
+
+func Example() {
+	ta, _ := table.New(true, [][]string{
+		{"Fruits:", "Count:"},
+		{"Apple", "4"},
+		{"Banana", "25"}}...)
+	printTable(ta)
+	// Output:
+	// Fruits: Count:
+	// ===============
+	// Apple   4
+	// Banana  25
+}
+
+func ExampleReadFrom() {
+	text := "Fruits:,Count:\nApple,4\nBanana,25\n"
+	r := strings.NewReader(text)
+	ta, _ := table.ReadFrom(r, true, []int{7, 6})
+	printTable(ta)
+	// Output:
+	// Fruits: Count:
+	// ===============
+	// Apple   4
+	// Banana  25
+}
+
+func ExampleNewStyle() {
+	ta, _ := table.New(true, [][]string{
+		{"Fruits:", "Count:"},
+		{"Apple", "4"},
+		{"Banana", "25"}}...)
+	ta.HeadStyle = table.NewStyle('o', '=', '|', true)
+	ta.BodyStyle = table.NewStyle('•', '–', '|', true)
+	ta.HeadOnlyBottomLine = false
+	ta.BottomLine = true
+	printTable(ta)
+	// Output:
+	// o========o=======o
+	// |Fruits: |Count: |
+	// o========o=======o
+	// |Apple   |4      |
+	// •––––––––•–––––––•
+	// |Banana  |25     |
+	// •––––––––•–––––––•
+}
+
+func ExampleTable_Span() {
+	ta, _ := table.New(true, [][]string{
+		{"Name", "Latency", "", ""},
+		{"foo", "1", "2", "3"}}...)
+	ta.Span(0, 1, 1, 3)
+	ta.HeadStyle = table.NewStyle('+', '=', '|', true)
+	ta.HeadOnlyBottomLine = false
+	printTable(ta)
+	// Output:
+	// +=====+========+
+	// |Name |Latency |
+	// +=====+==+==+==+
+	//  foo   1  2  3
+}
+
+func ExampleTable_headerRows() {
+	ta, _ := table.New(true, [][]string{
+		{"Name", "Latency", "Latency", "Latency"},
+		{"", "p50", "p95", "p99"},
+		{"foo", "1", "2", "3"}}...)
+	ta.HeaderRows = 2
+	printTable(ta)
+	// Output:
+	// Name Latency
+	//      ============
+	//      p50 p95 p99
+	// =================
+	// foo  1   2   3
+}
+
+func ExampleTable_BodyStyle() {
+	ta, _ := table.New(true, [][]string{
+		{"Fruits:", "Count:"},
+		{"Apple", "4"},
+		{"Banana", "25"}}...)
+	ta.BodyStyle = table.NewStyle(' ', ' ', '|', true)
+	printTable(ta)
+	// Output:
+	// Fruits:  Count:
+	// ==================
+	// |Apple   |4      |
+	// |Banana  |25     |
+}
+
+func ExampleNewWith() {
+	ta, err := table.NewWith([][]string{
+		{"Fruits:", "Count:"},
+		{"Apple", "4"},
+		{"Banana", "25"}},
+		table.WithHeader(1),
+		table.WithStyle(table.StyleBoring(), nil),
+		table.WithAlignment(row.AlignLeft, row.AlignRight))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	printTable(ta)
+	// Output:
+	// Fruits: Count:
+	// ===============
+	// Apple        4
+	// Banana      25
+}
@@ -0,0 +1,3 @@
+module github.com/thibran/table
+
+go 1.16
@@ -0,0 +1,60 @@
+package table
+
+import "github.com/thibran/table/row"
+
+// headerSpans returns the spans merged with the spans derived from the
+// header rows. Equal adjacent cells of all but the last header row are
+// merged into a group, as long as the group above does not end between
+// them. An empty header cell is merged with the cell above, so no line
+// is drawn between the levels of a column which is not grouped.
+func headerSpans(head [][]string, spans row.Spans) row.Spans {
+	s := append(row.Spans(nil), spans...)
+	for i := 0; i < len(head); i++ {
+		if i < len(head)-1 {
+			s = groupSpans(head, i, s)
+		}
+		if i > 0 {
+			s = extendSpans(head, i, s)
+		}
+	}
+	return s
+}
+
+// groupSpans merges equal adjacent cells of header row i.
+func groupSpans(head [][]string, i int, s row.Spans) row.Spans {
+	cells := head[i]
+	for j := 0; j < len(cells); {
+		n := 1
+		for j+n < len(cells) && cells[j] != "" && cells[j+n] == cells[j] &&
+			(i == 0 || s.Same(i-1, j+n-1, i-1, j+n)) {
+			n++
+		}
+		sp := row.Span{Row: i, Col: j, Rows: 1, Cols: n}
+		if n > 1 && !s.Overlaps(sp) {
+			s = append(s, sp)
+		}
+		j += n
+	}
+	return s
+}
+
+// extendSpans merges the empty cells of header row i with the cell above.
+func extendSpans(head [][]string, i int, s row.Spans) row.Spans {
+	for j, cell := range head[i] {
+		if _, ok := s.At(i, j); ok || cell != "" {
+			continue
+		}
+		above, ok := s.At(i-1, j)
+		switch {
+		case !ok:
+			s = append(s, row.Span{Row: i - 1, Col: j, Rows: 2, Cols: 1})
+		case above.Cols == 1 && above.Row+above.Rows == i:
+			for k := range s {
+				if s[k] == above {
+					s[k].Rows++
+				}
+			}
+		}
+	}
+	return s
+}
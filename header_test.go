@@ -0,0 +1,60 @@
+package table
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thibran/table/row"
+)
+
+func TestHeaderSpans_group(t *testing.T) {
+	head := [][]string{
+		{"Name", "Latency", "Latency", "Latency"},
+		{"", "p50", "p95", "p99"},
+	}
+	s := headerSpans(head, nil)
+	exp := row.Spans{
+		{Row: 0, Col: 1, Rows: 1, Cols: 3},
+		{Row: 0, Col: 0, Rows: 2, Cols: 1},
+	}
+	if !reflect.DeepEqual(s, exp) {
+		t.Errorf("should be %v but is %v", exp, s)
+	}
+}
+
+func TestHeaderSpans_groupBoundary(t *testing.T) {
+	head := [][]string{
+		{"A", "A", "B", "B"},
+		{"x", "x", "x", "x"},
+		{"1", "2", "3", "4"},
+	}
+	s := headerSpans(head, nil)
+	exp := row.Spans{
+		{Row: 0, Col: 0, Rows: 1, Cols: 2},
+		{Row: 0, Col: 2, Rows: 1, Cols: 2},
+		{Row: 1, Col: 0, Rows: 1, Cols: 2},
+		{Row: 1, Col: 2, Rows: 1, Cols: 2},
+	}
+	if !reflect.DeepEqual(s, exp) {
+		t.Errorf("should be %v but is %v", exp, s)
+	}
+}
+
+func TestHeaderSpans_lastRowNotGrouped(t *testing.T) {
+	head := [][]string{{"a", "a"}}
+	if s := headerSpans(head, nil); len(s) != 0 {
+		t.Errorf("should be empty but is %v", s)
+	}
+}
+
+func TestHeaderSpans_keepUserSpans(t *testing.T) {
+	head := [][]string{
+		{"a", "a", "a"},
+		{"1", "2", "3"},
+	}
+	user := row.Spans{{Row: 0, Col: 1, Rows: 1, Cols: 2}}
+	s := headerSpans(head, user)
+	if !reflect.DeepEqual(s, user) {
+		t.Errorf("should be %v but is %v", user, s)
+	}
+}
@@ -0,0 +1,96 @@
+package table
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/thibran/table/row"
+)
+
+// HTML renders the model as HTML table. The title is the <caption>,
+// footer rows and the caption are written into <tfoot>. The class of
+// the formatting rules is set as class attribute of a cell.
+type HTML struct {
+	Class string // class attribute of the table, if not empty
+}
+
+// Render the model m.
+func (h HTML) Render(w io.Writer, m *Model) error {
+	var b strings.Builder
+	if h.Class != "" {
+		fmt.Fprintf(&b, "<table class=\"%s\">\n", html.EscapeString(h.Class))
+	} else {
+		b.WriteString("<table>\n")
+	}
+	if m.Title != "" {
+		fmt.Fprintf(&b, "  <caption>%s</caption>\n", htmlText(m.Title))
+	}
+	htmlSection(&b, "thead", "th", m.Header, m.Columns)
+	htmlSection(&b, "tbody", "td", m.Body, m.Columns)
+	if len(m.Footer) > 0 || m.Caption != "" {
+		b.WriteString("  <tfoot>\n")
+		htmlRows(&b, "td", m.Footer, m.Columns)
+		if m.Caption != "" {
+			caption := []Cell{{Text: m.Caption, Rows: 1, Cols: len(m.Columns)}}
+			htmlRows(&b, "td", [][]Cell{caption}, nil)
+		}
+		b.WriteString("  </tfoot>\n")
+	}
+	b.WriteString("</table>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// htmlSection writes the rows as section, e.g. <tbody>, with cells of
+// the element tag.
+func htmlSection(b *strings.Builder, section, tag string, rows [][]Cell, cols []Column) {
+	if len(rows) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "  <%s>\n", section)
+	htmlRows(b, tag, rows, cols)
+	fmt.Fprintf(b, "  </%s>\n", section)
+}
+
+// htmlRows writes the rows with cells of the element tag.
+func htmlRows(b *strings.Builder, tag string, rows [][]Cell, cols []Column) {
+	for _, cells := range rows {
+		b.WriteString("    <tr>")
+		for j, c := range cells {
+			if c.Merged {
+				continue
+			}
+			fmt.Fprintf(b, "<%s%s>", tag, htmlAttrs(c, j, cols))
+			s := htmlText(c.Text)
+			if c.Link != "" {
+				s = fmt.Sprintf("<a href=\"%s\">%s</a>", html.EscapeString(c.Link), s)
+			}
+			fmt.Fprintf(b, "%s</%s>", s, tag)
+		}
+		b.WriteString("</tr>\n")
+	}
+}
+
+func htmlAttrs(c Cell, j int, cols []Column) string {
+	var a string
+	if c.Class != "" {
+		a += fmt.Sprintf(" class=\"%s\"", html.EscapeString(c.Class))
+	}
+	if j < len(cols) && cols[j].Align != row.AlignLeft {
+		a += fmt.Sprintf(" style=\"text-align: %s\"", cols[j].Align)
+	}
+	if c.Cols > 1 {
+		a += fmt.Sprintf(" colspan=\"%d\"", c.Cols)
+	}
+	if c.Rows > 1 {
+		a += fmt.Sprintf(" rowspan=\"%d\"", c.Rows)
+	}
+	return a
+}
+
+// htmlText escapes s and replaces line breaks with <br>.
+func htmlText(s string) string {
+	return strings.Replace(html.EscapeString(s), "\n", "<br>", -1)
+}
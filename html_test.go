@@ -0,0 +1,42 @@
+package table
+
+import "testing"
+
+func TestHTML(t *testing.T) {
+	ta := newModelTable()
+	ta.Caption = "in <ms>"
+	ta.Renderer = HTML{Class: "bench"}
+	exp := `<table class="bench">
+  <caption>T</caption>
+  <thead>
+    <tr><th rowspan="2">Name</th><th style="text-align: right" colspan="2">Latency</th></tr>
+    <tr><th style="text-align: right">p50</th><th style="text-align: right">p99</th></tr>
+  </thead>
+  <tbody>
+    <tr><td><a href="http://a">a</a></td><td style="text-align: right">1</td><td class="bad" style="text-align: right">95</td></tr>
+  </tbody>
+  <tfoot>
+    <tr><td>Total</td><td style="text-align: right">1</td><td class="bad" style="text-align: right">95</td></tr>
+    <tr><td colspan="3">in &lt;ms&gt;</td></tr>
+  </tfoot>
+</table>
+`
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestHTML_noHeader(t *testing.T) {
+	ta, _ := New(false, [][]string{{"a&b", "c"}}...)
+	ta.Renderer = HTML{}
+	exp := "<table>\n  <tbody>\n    <tr><td>a&amp;b</td><td>c</td></tr>\n  </tbody>\n</table>\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestHTMLText(t *testing.T) {
+	if s := htmlText("<a>\nb"); s != "&lt;a&gt;<br>b" {
+		t.Errorf("should be %q but is %q", "&lt;a&gt;<br>b", s)
+	}
+}
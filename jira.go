@@ -0,0 +1,78 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Jira renders the model in the wiki markup of Jira and Confluence. Header
+// rows are written as ||cell|| rows, body and footer rows as |cell| rows.
+// The markup can not merge cells, merged cells are empty. The title is
+// written in bold above and the caption below the table.
+type Jira struct{}
+
+// Render the model m.
+func (Jira) Render(w io.Writer, m *Model) error {
+	var b strings.Builder
+	if m.Title != "" {
+		fmt.Fprintf(&b, "*%s*\n\n", jiraEscape(m.Title))
+	}
+	for i, cells := range m.rows() {
+		sep := "|"
+		if i < len(m.Header) {
+			sep = "||"
+		}
+		b.WriteString(sep)
+		for _, c := range cells {
+			s := ""
+			if !c.Merged {
+				s = jiraText(c)
+			}
+			if s == "" {
+				// an empty cell would join the separators
+				s = " "
+			}
+			b.WriteString(s + sep)
+		}
+		b.WriteString("\n")
+	}
+	if m.Caption != "" {
+		fmt.Fprintf(&b, "\n%s\n", jiraEscape(m.Caption))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// jiraText returns the escaped text of c, links are written as [text|url].
+func jiraText(c Cell) string {
+	s := jiraEscape(c.Text)
+	if c.Link == "" {
+		return s
+	}
+	if s == "" {
+		return "[" + c.Link + "]"
+	}
+	return "[" + s + "|" + c.Link + "]"
+}
+
+var jiraReplacer = strings.NewReplacer(
+	`|`, `\|`,
+	`[`, `\[`,
+	`]`, `\]`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`*`, `\*`,
+	`_`, `\_`,
+	`!`, `\!`,
+	`^`, `\^`,
+	`~`, `\~`,
+	"\r\n", " ",
+	"\n", " ",
+)
+
+// jiraEscape escapes the characters of the wiki markup, which start a
+// text effect, macro, link or image, and replaces line breaks.
+func jiraEscape(s string) string {
+	return jiraReplacer.Replace(s)
+}
@@ -0,0 +1,30 @@
+package table
+
+import "testing"
+
+func TestJira(t *testing.T) {
+	ta := newModelTable()
+	ta.Caption = "c"
+	ta.Renderer = Jira{}
+	exp := `*T*
+
+||Name||Latency|| ||
+|| ||p50||p99||
+|[a|http://a]|1|95|
+|Total|1|95|
+
+c
+`
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestJira_escape(t *testing.T) {
+	ta, _ := New(true, [][]string{{"a|b", "x"}, {"", "[y]_*"}}...)
+	ta.Renderer = Jira{}
+	exp := "||a\\|b||x||\n| |\\[y\\]\\_\\*|\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
@@ -0,0 +1,114 @@
+package table
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+)
+
+// JSON renders the body rows of the model as array of objects keyed by
+// the column names, or as array of arrays if the model has no header.
+// Every row is written on its own line. Cells which are numbers or
+// booleans are written as such, unless Strings is true.
+type JSON struct {
+	Columns []string // names of the written columns in this order, all if empty
+	Strings bool     // if true, all cells are written as strings
+}
+
+// Render the model m.
+func (j JSON) Render(w io.Writer, m *Model) error {
+	cols, err := project(m, j.Columns)
+	if err != nil {
+		return err
+	}
+	var b bytes.Buffer
+	b.WriteString("[")
+	for i, cells := range m.Body {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n  ")
+		if len(m.Header) == 0 {
+			b.WriteString("[")
+		} else {
+			b.WriteString("{")
+		}
+		for k, c := range cols {
+			switch {
+			case k > 0 && len(m.Header) == 0:
+				b.WriteString(",")
+			case k > 0:
+				b.WriteString(", ")
+			}
+			if len(m.Header) > 0 {
+				b.Write(jsonString(m.Columns[c].Name))
+				b.WriteString(": ")
+			}
+			b.Write(jsonValue(cells[c].Text, j.Strings))
+		}
+		if len(m.Header) == 0 {
+			b.WriteString("]")
+		} else {
+			b.WriteString("}")
+		}
+	}
+	if len(m.Body) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString("]\n")
+	_, err = w.Write(b.Bytes())
+	return err
+}
+
+// project returns the indices of the columns of m with the names, or of
+// all columns if names is empty.
+func project(m *Model, names []string) ([]int, error) {
+	if len(names) == 0 {
+		cols := make([]int, len(m.Columns))
+		for j := range cols {
+			cols[j] = j
+		}
+		return cols, nil
+	}
+	cols := make([]int, len(names))
+	for k, name := range names {
+		cols[k] = -1
+		for j, c := range m.Columns {
+			if c.Name == name {
+				cols[k] = j
+				break
+			}
+		}
+		if cols[k] < 0 {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+	}
+	return cols, nil
+}
+
+var jsonNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// isTyped returns true if s is a JSON number or boolean.
+func isTyped(s string) bool {
+	return s == "true" || s == "false" || jsonNumber.MatchString(s)
+}
+
+// jsonValue returns s as JSON number or boolean if it is one and
+// strings is false, otherwise as JSON string.
+func jsonValue(s string, strings bool) []byte {
+	if !strings && isTyped(s) {
+		return []byte(s)
+	}
+	return jsonString(s)
+}
+
+// jsonString returns s as JSON string, without escaping HTML.
+func jsonString(s string) []byte {
+	var b bytes.Buffer
+	e := json.NewEncoder(&b)
+	e.SetEscapeHTML(false)
+	e.Encode(s)
+	return bytes.TrimSuffix(b.Bytes(), []byte("\n"))
+}
@@ -0,0 +1,58 @@
+package table
+
+import (
+	"io"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	ta := newModelTable()
+	ta.Renderer = JSON{}
+	exp := "[\n  {\"Name\": \"a\", \"Latency p50\": 1, \"Latency p99\": 95}\n]\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestJSON_noHeader(t *testing.T) {
+	ta, _ := New(false, [][]string{{"a", "b"}, {"c"}}...)
+	ta.Renderer = JSON{}
+	exp := "[\n  [\"a\",\"b\"],\n  [\"c\",\"\"]\n]\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestJSON_empty(t *testing.T) {
+	ta, _ := New(true, [][]string{{"a"}}...)
+	ta.Renderer = JSON{}
+	if s := ta.String(); s != "[]\n" {
+		t.Errorf("should be %q but is %q", "[]\n", s)
+	}
+}
+
+func TestJSON_project(t *testing.T) {
+	ta := newModelTable()
+	ta.Renderer = JSON{Columns: []string{"Latency p99", "Name"}, Strings: true}
+	exp := "[\n  {\"Latency p99\": \"95\", \"Name\": \"a\"}\n]\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestJSON_unknownColumn(t *testing.T) {
+	ta := newModelTable()
+	ta.Renderer = JSON{Columns: []string{"x"}}
+	if _, err := ta.WriteTo(io.Discard); err == nil {
+		t.Errorf("unknown column should fail")
+	}
+}
+
+func TestJSON_typed(t *testing.T) {
+	ta, _ := New(false, [][]string{{"-1.5e3", "01", "true", "1.", "<a>", ""}}...)
+	ta.Renderer = JSON{}
+	exp := "[\n  [-1.5e3,\"01\",true,\"1.\",\"<a>\",\"\"]\n]\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
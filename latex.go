@@ -0,0 +1,152 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/thibran/table/row"
+)
+
+// LaTeX renders the model as tabular environment. The column alignment
+// is derived from the model, cells spanning several columns are written
+// as \multicolumn. A title wraps the tabular into a table environment
+// with \caption, the caption of the model is written below the tabular.
+type LaTeX struct {
+	Booktabs bool // if true, the rules of the booktabs package are used instead of \hline
+}
+
+// Render the model m.
+func (l LaTeX) Render(w io.Writer, m *Model) error {
+	var b strings.Builder
+	if m.Title != "" {
+		b.WriteString("\\begin{table}\n\\centering\n")
+		fmt.Fprintf(&b, "\\caption{%s}\n", latexEscape(m.Title))
+	}
+	spec := make([]string, len(m.Columns))
+	for j, c := range m.Columns {
+		spec[j] = latexAlign(c.Align)
+	}
+	fmt.Fprintf(&b, "\\begin{tabular}{%s}\n", strings.Join(spec, ""))
+	b.WriteString(l.rule("\\toprule"))
+	for i, cells := range m.Header {
+		l.writeRow(&b, cells, m.Columns, true)
+		if i < len(m.Header)-1 {
+			b.WriteString(l.groupRules(cells))
+		}
+	}
+	if len(m.Header) > 0 {
+		b.WriteString(l.rule("\\midrule"))
+	}
+	for _, cells := range m.Body {
+		l.writeRow(&b, cells, m.Columns, false)
+	}
+	if len(m.Footer) > 0 {
+		b.WriteString(l.rule("\\midrule"))
+	}
+	for _, cells := range m.Footer {
+		l.writeRow(&b, cells, m.Columns, false)
+	}
+	b.WriteString(l.rule("\\bottomrule"))
+	b.WriteString("\\end{tabular}\n")
+	if m.Caption != "" {
+		fmt.Fprintf(&b, "\n%s\n", latexEscape(m.Caption))
+	}
+	if m.Title != "" {
+		b.WriteString("\\end{table}\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// rule returns the booktabs rule or \hline.
+func (l LaTeX) rule(booktabs string) string {
+	if l.Booktabs {
+		return booktabs + "\n"
+	}
+	return "\\hline\n"
+}
+
+// groupRules returns the rules below the cells spanning several columns
+// of a header row.
+func (l LaTeX) groupRules(cells []Cell) string {
+	var s string
+	for j, c := range cells {
+		if c.Merged || c.Cols < 2 || c.Rows > 1 {
+			continue
+		}
+		if l.Booktabs {
+			s += fmt.Sprintf("\\cmidrule(lr){%d-%d}", j+1, j+c.Cols)
+		} else {
+			s += fmt.Sprintf("\\cline{%d-%d}", j+1, j+c.Cols)
+		}
+	}
+	if s == "" {
+		return ""
+	}
+	return s + "\n"
+}
+
+// writeRow writes the cells, header cells spanning several columns are
+// centered. A cell merged with the cell above is empty.
+func (l LaTeX) writeRow(b *strings.Builder, cells []Cell, cols []Column, header bool) {
+	var parts []string
+	for j := 0; j < len(cells); j++ {
+		c := cells[j]
+		s := latexEscape(c.Text)
+		if c.Merged {
+			s = ""
+		}
+		if c.Link != "" {
+			s = fmt.Sprintf("\\href{%s}{%s}", latexURL(c.Link), s)
+		}
+		if !c.Merged && c.Cols > 1 {
+			a := latexAlign(cols[j].Align)
+			if header {
+				a = "c"
+			}
+			s = fmt.Sprintf("\\multicolumn{%d}{%s}{%s}", c.Cols, a, s)
+			j += c.Cols - 1
+		}
+		parts = append(parts, s)
+	}
+	b.WriteString(strings.Join(parts, " & ") + " \\\\\n")
+}
+
+func latexAlign(a row.Align) string {
+	switch a {
+	case row.AlignRight:
+		return "r"
+	case row.AlignCenter:
+		return "c"
+	}
+	return "l"
+}
+
+var latexReplacer = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+	`<`, `\textless{}`,
+	`>`, `\textgreater{}`,
+	`|`, `\textbar{}`,
+	"\r\n", " ",
+	"\n", " ",
+)
+
+// latexEscape escapes the special characters of LaTeX in s.
+func latexEscape(s string) string {
+	return latexReplacer.Replace(s)
+}
+
+// latexURL escapes the characters of url, which end or break \href.
+func latexURL(url string) string {
+	return strings.NewReplacer(`\`, `\\`, `#`, `\#`, `%`, `\%`, `{`, `\{`, `}`, `\}`).Replace(url)
+}
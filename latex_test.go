@@ -0,0 +1,71 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/thibran/table/row"
+)
+
+func TestLaTeX_booktabs(t *testing.T) {
+	ta := newModelTable()
+	ta.Title = ""
+	ta.Renderer = LaTeX{Booktabs: true}
+	exp := `\begin{tabular}{lrr}
+\toprule
+Name & \multicolumn{2}{c}{Latency} \\
+\cmidrule(lr){2-3}
+ & p50 & p99 \\
+\midrule
+\href{http://a}{a} & 1 & 95 \\
+\midrule
+Total & 1 & 95 \\
+\bottomrule
+\end{tabular}
+`
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestLaTeX_title(t *testing.T) {
+	ta, _ := New(true, [][]string{{"a_b", "c"}, {"50%", "x"}}...)
+	ta.Title = "R&D"
+	ta.Caption = "$5"
+	ta.Renderer = LaTeX{}
+	exp := `\begin{table}
+\centering
+\caption{R\&D}
+\begin{tabular}{ll}
+\hline
+a\_b & c \\
+\hline
+50\% & x \\
+\hline
+\end{tabular}
+
+\$5
+\end{table}
+`
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestLaTeX_bodySpan(t *testing.T) {
+	ta, _ := New(false, [][]string{{"a", "b", "c"}}...)
+	ta.Span(0, 0, 1, 2)
+	ta.Align = []row.Align{row.AlignRight}
+	ta.Renderer = LaTeX{}
+	exp := "\\begin{tabular}{rrr}\n\\hline\n\\multicolumn{2}{r}{a} & c \\\\\n\\hline\n\\end{tabular}\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestLatexEscape(t *testing.T) {
+	s := latexEscape(`\{}~^#<>|` + "\n")
+	exp := `\textbackslash{}\{\}\textasciitilde{}\textasciicircum{}\#\textless{}\textgreater{}\textbar{} `
+	if s != exp {
+		t.Errorf("should be %q but is %q", exp, s)
+	}
+}
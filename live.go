@@ -0,0 +1,130 @@
+package table
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/thibran/table/row"
+)
+
+var errLiveRange = errors.New("cell is out of range")
+
+// Live is a table which is redrawn in place on a terminal, e.g. for
+// progress dashboards. Its cells can be updated from several goroutines,
+// Draw only rewrites the lines which changed since the last call.
+type Live struct {
+	mu    sync.Mutex
+	t     Table      // settings of the drawn table
+	rows  [][]string // including the header rows
+	lines []string   // lines of the last drawn table
+	w     io.Writer
+}
+
+// NewLive reads all rows of t and returns a Live table drawn to w with
+// the settings of t. Later changes to t are ignored.
+func NewLive(w io.Writer, t *Table) (*Live, error) {
+	var rows [][]string
+	t.rewind()
+	if t.r != nil {
+		var err error
+		if rows, err = t.r.ReadAll(); err != nil {
+			return nil, err
+		}
+	}
+	return &Live{t: *t, rows: rows, w: w}, nil
+}
+
+// Set the text of the cell at row i, column j. The row index counts the
+// header rows as well. Missing cells of row i are added.
+func (l *Live) Set(i, j int, text string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if i < 0 || i >= len(l.rows) || j < 0 {
+		return errLiveRange
+	}
+	for len(l.rows[i]) <= j {
+		l.rows[i] = append(l.rows[i], "")
+	}
+	l.rows[i][j] = text
+	return nil
+}
+
+// SetRow replaces the cells of row i.
+func (l *Live) SetRow(i int, cells ...string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if i < 0 || i >= len(l.rows) {
+		return errLiveRange
+	}
+	l.rows[i] = append([]string(nil), cells...)
+	return nil
+}
+
+// Append a row and return its index.
+func (l *Live) Append(cells ...string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rows = append(l.rows, append([]string(nil), cells...))
+	return len(l.rows) - 1
+}
+
+// Draw the table. The first call writes the whole table, later calls
+// move the cursor up to the first line and only rewrite changed lines.
+// The cursor is left on the last line of the table.
+func (l *Live) Draw() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lines, err := l.render()
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	if up := len(l.lines) - 1; up > 0 {
+		fmt.Fprintf(&b, "\x1b[%dA", up)
+	}
+	shrink := len(lines) < len(l.lines)
+	for k, s := range lines {
+		if k > 0 {
+			b.WriteString("\n")
+		}
+		last := k == len(lines)-1
+		if k < len(l.lines) && l.lines[k] == s && !(last && shrink) {
+			continue
+		}
+		b.WriteString("\r" + s + "\x1b[K")
+	}
+	if shrink {
+		b.WriteString("\x1b[J")
+	}
+	if _, err := io.WriteString(l.w, b.String()); err != nil {
+		return err
+	}
+	l.lines = lines
+	return nil
+}
+
+// render draws a copy of the table and returns its lines.
+func (l *Live) render() ([]string, error) {
+	t := l.t
+	t.rows = make([][]string, len(l.rows))
+	for i := range l.rows {
+		t.rows[i] = append([]string(nil), l.rows[i]...)
+	}
+	t.r = newReader(t.rows)
+	c := row.NewColumnCap(t.rows, 0)
+	for j := range c {
+		if j < len(t.columnCap) && t.isFixed(j) {
+			c[j] = t.columnCap[j]
+		}
+	}
+	t.columnCap = c
+	var buf bytes.Buffer
+	if _, err := t.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return strings.Split(buf.String(), "\n"), nil
+}
@@ -0,0 +1,117 @@
+package table
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func newTestLive(t *testing.T, b *bytes.Buffer) *Live {
+	ta, _ := New(true, [][]string{
+		{"job", "state"},
+		{"a", "run"},
+		{"b", "run"}}...)
+	l, err := NewLive(b, ta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+func TestLive_draw(t *testing.T) {
+	var b bytes.Buffer
+	l := newTestLive(t, &b)
+	if err := l.Draw(); err != nil {
+		t.Fatal(err)
+	}
+	exp := "\rjob state \x1b[K\n\r==========\x1b[K\n\ra   run   \x1b[K\n\rb   run   \x1b[K"
+	if s := b.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestLive_drawChanged(t *testing.T) {
+	var b bytes.Buffer
+	l := newTestLive(t, &b)
+	l.Draw()
+	b.Reset()
+	l.Set(1, 1, "done")
+	l.Draw()
+	exp := "\x1b[3A\n\n\ra   done  \x1b[K\n"
+	if s := b.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestLive_drawGrow(t *testing.T) {
+	var b bytes.Buffer
+	l := newTestLive(t, &b)
+	l.Draw()
+	b.Reset()
+	if i := l.Append("c", "new"); i != 3 {
+		t.Errorf("should be %d but is %d", 3, i)
+	}
+	l.Draw()
+	exp := "\x1b[3A\n\n\n\n\rc   new   \x1b[K"
+	if s := b.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestLive_drawWider(t *testing.T) {
+	var b bytes.Buffer
+	ta, _ := New(false, [][]string{{"a"}, {"b"}}...)
+	l, _ := NewLive(&b, ta)
+	l.Draw()
+	b.Reset()
+	l.SetRow(0, "a", "b")
+	l.Draw()
+	exp := "\x1b[1A\ra b \x1b[K\n\rb   \x1b[K"
+	if s := b.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestLive_drawFixedWidths(t *testing.T) {
+	var b bytes.Buffer
+	ta, _ := NewWith([][]string{{"a", "b"}, {"c", "d"}}, WithWidths(5, 0))
+	l, _ := NewLive(&b, ta)
+	l.Draw()
+	b.Reset()
+	l.SetRow(1, "c", "ddd")
+	l.Draw()
+	exp := "\x1b[1A\ra     b   \x1b[K\n\rc     ddd \x1b[K"
+	if s := b.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestLive_setRange(t *testing.T) {
+	var b bytes.Buffer
+	l := newTestLive(t, &b)
+	if err := l.Set(3, 0, "x"); err != errLiveRange {
+		t.Errorf("should be %v but is %v", errLiveRange, err)
+	}
+	if err := l.SetRow(-1); err != errLiveRange {
+		t.Errorf("should be %v but is %v", errLiveRange, err)
+	}
+}
+
+func TestLive_concurrent(t *testing.T) {
+	var b bytes.Buffer
+	l := newTestLive(t, &b)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			l.Set(1+i%2, 1, "x")
+			l.Append("c")
+			l.Draw()
+		}(i)
+	}
+	wg.Wait()
+	if len(l.rows) != 13 {
+		t.Errorf("should be %d rows but are %d", 13, len(l.rows))
+	}
+}
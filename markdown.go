@@ -0,0 +1,91 @@
+package table
+
+import (
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/thibran/table/row"
+)
+
+// Markdown renders the model as GitHub flavored Markdown table. The
+// header is a single row with the column names, the title is written in
+// bold above and the caption below the table. Merged cells are empty.
+type Markdown struct{}
+
+// Render the model m.
+func (Markdown) Render(w io.Writer, m *Model) error {
+	rows := [][]string{make([]string, len(m.Columns))}
+	for j, c := range m.Columns {
+		rows[0][j] = mdEscape(c.Name)
+	}
+	for _, cells := range append(m.Body, m.Footer...) {
+		r := make([]string, len(cells))
+		for j, c := range cells {
+			if c.Merged {
+				continue
+			}
+			r[j] = mdEscape(c.Text)
+			if c.Link != "" {
+				r[j] = "[" + r[j] + "](" + c.Link + ")"
+			}
+		}
+		rows = append(rows, r)
+	}
+	width := make([]int, len(m.Columns))
+	for j := range width {
+		width[j] = 3
+		for _, r := range rows {
+			if n := utf8.RuneCountInString(r[j]); n > width[j] {
+				width[j] = n
+			}
+		}
+	}
+	var b strings.Builder
+	if m.Title != "" {
+		b.WriteString("**" + mdEscape(m.Title) + "**\n\n")
+	}
+	for i, r := range rows {
+		mdRow(&b, r, width, m.Columns)
+		if i == 0 {
+			mdRule(&b, width, m.Columns)
+		}
+	}
+	if m.Caption != "" {
+		b.WriteString("\n" + mdEscape(m.Caption) + "\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func mdRow(b *strings.Builder, cells []string, width []int, cols []Column) {
+	b.WriteString("|")
+	for j, s := range cells {
+		b.WriteString(" " + cols[j].Align.Pad(s, width[j]) + " |")
+	}
+	b.WriteString("\n")
+}
+
+// mdRule writes the line below the header, which sets the alignment.
+func mdRule(b *strings.Builder, width []int, cols []Column) {
+	b.WriteString("|")
+	for j, w := range width {
+		s := strings.Repeat("-", w)
+		switch cols[j].Align {
+		case row.AlignRight:
+			s = s[1:] + ":"
+		case row.AlignCenter:
+			s = ":" + s[2:] + ":"
+		}
+		b.WriteString(" " + s + " |")
+	}
+	b.WriteString("\n")
+}
+
+// mdEscape escapes the pipe and replaces line breaks, which would end
+// the table row.
+func mdEscape(s string) string {
+	s = strings.Replace(s, "|", "\\|", -1)
+	s = strings.Replace(s, "\r\n", "<br>", -1)
+	return strings.Replace(s, "\n", "<br>", -1)
+}
@@ -0,0 +1,33 @@
+package table
+
+import "testing"
+
+func TestMarkdown(t *testing.T) {
+	ta := newModelTable()
+	ta.Caption = "in ms"
+	ta.Renderer = Markdown{}
+	exp := "**T**\n\n" +
+		"| Name          | Latency p50 | Latency p99 |\n" +
+		"| ------------- | ----------: | ----------: |\n" +
+		"| [a](http://a) |           1 |          95 |\n" +
+		"| Total         |           1 |          95 |\n" +
+		"\nin ms\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestMarkdown_escape(t *testing.T) {
+	ta, _ := New(false, [][]string{{"a|b", "c"}}...)
+	ta.Renderer = Markdown{}
+	exp := "|      |     |\n| ---- | --- |\n| a\\|b | c   |\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestMdEscape(t *testing.T) {
+	if s := mdEscape("a|b\r\nc\nd"); s != "a\\|b<br>c<br>d" {
+		t.Errorf("should be %q but is %q", "a\\|b<br>c<br>d", s)
+	}
+}
@@ -0,0 +1,169 @@
+package table
+
+import (
+	"io"
+	"strings"
+
+	"github.com/thibran/table/row"
+)
+
+// Model is the content of a table passed to a Renderer. The number column
+// is the first column, if the table has one. The top-left cell of a span
+// has the number of rows and columns it covers, the other cells of the
+// span are Merged.
+type Model struct {
+	Title   string
+	Caption string
+	Columns []Column
+	Header  [][]Cell
+	Body    [][]Cell
+	Footer  [][]Cell // the last FooterRows rows of the table
+}
+
+// Column of a Model.
+type Column struct {
+	Name  string // texts of the header rows, joined by whitespace
+	Width int    // width of the text in runes, without padding
+	Align row.Align
+}
+
+// Cell of a Model.
+type Cell struct {
+	Text   string    // text after the formatting rules are applied
+	Link   string    // link target, if any
+	Class  string    // CSS class of the formatting rules
+	Paint  row.Paint // terminal paint of the row and the formatting rules
+	Rows   int       // rows covered by the cell, 1 if it is not merged
+	Cols   int       // columns covered by the cell, 1 if it is not merged
+	Merged bool      // true if the cell is covered by the span of another cell
+}
+
+// Renderer writes a Model, e.g. as Markdown or HTML.
+type Renderer interface {
+	Render(w io.Writer, m *Model) error
+}
+
+// Model reads all rows of the table and returns its content. Like
+// WriteTo, it can only be called once for tables created by ReadFrom.
+// The number column holds the bare numbers and is aligned right.
+func (t *Table) Model() (*Model, error) {
+	t.rewind()
+	recs, err := t.r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	head := recs
+	if len(head) > t.HeaderRows {
+		head = head[:t.HeaderRows]
+	}
+	d := t.newDraw(io.Discard)
+	l := t.layout(d, head)
+	m := &Model{
+		Title:   t.Title,
+		Caption: t.Caption,
+		Columns: make([]Column, len(d.ColumnCap)),
+	}
+	offset := len(l.pads) - len(t.columnCap)
+	for j := range m.Columns {
+		m.Columns[j].Width = d.ColumnCap[j] - l.pads[j].Left - l.pads[j].Right
+		if j >= offset {
+			m.Columns[j].Align = t.align(j - offset)
+		} else {
+			m.Columns[j].Align = row.AlignRight
+		}
+	}
+	for i, b := range recs {
+		footer := t.isFooter(i, len(recs))
+		cells := t.modelRow(i, b, l, d.Spans, len(m.Columns), footer)
+		switch {
+		case i < len(head):
+			m.Header = append(m.Header, cells)
+		case footer:
+			m.Footer = append(m.Footer, cells)
+		default:
+			m.Body = append(m.Body, cells)
+		}
+	}
+	m.names()
+	return m, nil
+}
+
+// modelRow returns the n cells of row i, see writeRow. Footer rows have
+// no number.
+func (t *Table) modelRow(i int, b []string, l layout, spans row.Spans, n int,
+	footer bool) []Cell {
+	b = append([]string(nil), b...)
+	p := t.rowPaint(i, b)
+	var f []row.Format
+	if i >= t.HeaderRows && len(t.Rules) > 0 {
+		f = t.Rules.Apply(b)
+	}
+	offset := 0
+	if t.Numbering != nil {
+		num := t.Numbering.cell(i, t.HeaderRows, 0)
+		if footer {
+			num = ""
+		}
+		b = append([]string{num}, b...)
+		offset = 1
+	}
+	cells := make([]Cell, n)
+	for j := range cells {
+		c := Cell{Rows: 1, Cols: 1, Paint: p}
+		if j < len(b) {
+			c.Text = b[j]
+		}
+		if k := j - offset; k >= 0 {
+			if k < len(f) {
+				c.Class = f[k].Class
+				c.Paint = c.Paint.Add(f[k].Paint)
+			}
+			c.Link = t.links[pos{i, k}]
+		}
+		if sp, ok := spans.At(i, j); ok {
+			if sp.Row == i && sp.Col == j {
+				c.Rows, c.Cols = sp.Rows, sp.Cols
+			} else {
+				c = Cell{Rows: 1, Cols: 1, Merged: true}
+			}
+		}
+		cells[j] = c
+	}
+	return cells
+}
+
+// names sets the name of every column to the texts of its header cells,
+// merged cells take the text of their span.
+func (m *Model) names() {
+	for j := range m.Columns {
+		var parts []string
+		for i := range m.Header {
+			s := m.text(m.Header, i, j)
+			if s != "" && (len(parts) == 0 || parts[len(parts)-1] != s) {
+				parts = append(parts, s)
+			}
+		}
+		m.Columns[j].Name = strings.Join(parts, " ")
+	}
+}
+
+// text returns the text of the cell at row i, column j of rows. A merged
+// cell returns the text of the span covering it.
+func (m *Model) text(rows [][]Cell, i, j int) string {
+	for a := i; a >= 0; a-- {
+		for b := j; b >= 0; b-- {
+			c := rows[a][b]
+			if !c.Merged && a+c.Rows > i && b+c.Cols > j {
+				return c.Text
+			}
+		}
+	}
+	return ""
+}
+
+// rows returns all rows of the model.
+func (m *Model) rows() [][]Cell {
+	rows := append([][]Cell(nil), m.Header...)
+	rows = append(rows, m.Body...)
+	return append(rows, m.Footer...)
+}
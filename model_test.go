@@ -0,0 +1,97 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/thibran/table/row"
+)
+
+func newModelTable() *Table {
+	ta, _ := New(true, [][]string{
+		{"Name", "Latency", "Latency"},
+		{"", "p50", "p99"},
+		{"a", "1", "95"},
+		{"Total", "1", "95"}}...)
+	ta.HeaderRows = 2
+	ta.FooterRows = 1
+	ta.Title = "T"
+	ta.Rules = row.Rules{{Column: 2, Match: row.Greater(90), Paint: row.Red, Class: "bad"}}
+	ta.Link(2, 0, "http://a")
+	ta.Align = []row.Align{row.AlignLeft, row.AlignRight}
+	return ta
+}
+
+func TestTable_model(t *testing.T) {
+	m, err := newModelTable().Model()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Title != "T" || len(m.Header) != 2 || len(m.Body) != 1 || len(m.Footer) != 1 {
+		t.Fatalf("wrong model: %+v", m)
+	}
+	exp := []Column{
+		{Name: "Name", Width: 5, Align: row.AlignLeft},
+		{Name: "Latency p50", Width: 3, Align: row.AlignRight},
+		{Name: "Latency p99", Width: 3, Align: row.AlignRight},
+	}
+	for j := range exp {
+		if m.Columns[j] != exp[j] {
+			t.Errorf("column %d should be %+v but is %+v", j, exp[j], m.Columns[j])
+		}
+	}
+	if c := m.Header[0][1]; c.Text != "Latency" || c.Cols != 2 || c.Rows != 1 {
+		t.Errorf("group should span 2 columns: %+v", c)
+	}
+	if c := m.Header[0][2]; !c.Merged || c.Text != "" {
+		t.Errorf("cell should be merged: %+v", c)
+	}
+	if c := m.Header[0][0]; c.Rows != 2 || !m.Header[1][0].Merged {
+		t.Errorf("name should span 2 rows: %+v", c)
+	}
+	if c := m.Body[0][0]; c.Link != "http://a" {
+		t.Errorf("should have link: %+v", c)
+	}
+	if c := m.Body[0][2]; c.Class != "bad" || c.Paint != row.Red {
+		t.Errorf("rule should be applied: %+v", c)
+	}
+}
+
+func TestTable_modelNumbering(t *testing.T) {
+	ta := newModelTable()
+	ta.Numbering = NewNumbering(1)
+	m, _ := ta.Model()
+	if m.Columns[0].Name != "#" || m.Body[0][0].Text != "1" || m.Footer[0][0].Text != "" {
+		t.Errorf("wrong number column: %+v", m)
+	}
+	if m.Body[0][1].Link != "http://a" {
+		t.Errorf("link should be moved: %+v", m.Body[0])
+	}
+}
+
+func TestTable_modelBareNumbers(t *testing.T) {
+	rows := [][]string{{"x"}}
+	for i := 0; i < 10; i++ {
+		rows = append(rows, []string{"a"})
+	}
+	ta, _ := New(true, rows...)
+	ta.Numbering = NewNumbering(1)
+	m, _ := ta.Model()
+	if m.Body[0][0].Text != "1" || m.Body[9][0].Text != "10" || m.Columns[0].Align != row.AlignRight {
+		t.Errorf("the number column should hold bare numbers: %q %q %v",
+			m.Body[0][0].Text, m.Body[9][0].Text, m.Columns[0].Align)
+	}
+}
+
+func TestTable_modelTwice(t *testing.T) {
+	ta := newModelTable()
+	s := ta.String()
+	if _, err := ta.Model(); err != nil {
+		t.Fatal(err)
+	}
+	if m, _ := ta.Model(); len(m.Body) != 1 {
+		t.Errorf("the rows should be read again: %+v", m)
+	}
+	if s2 := ta.String(); s2 != s {
+		t.Errorf("the table should be drawn again:\n%s\n%s", s, s2)
+	}
+}
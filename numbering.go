@@ -0,0 +1,90 @@
+package table
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/thibran/table/row"
+)
+
+// Numbering adds a column in front of the table, which contains the
+// number of every body row. The column is drawn like the header.
+type Numbering struct {
+	Start int    // number of the first body row, e.g. 0 or 1
+	Title string // header of the column, e.g. "#"
+	Width int    // minimal count of digits, if the number of rows is unknown
+
+	// Original numbers the body rows by their index in the source, so a
+	// row keeps its number when a View sorts the rows. Source is that
+	// index of every body row, recorded before the rows were sorted or
+	// filtered, nil if they are in the order of the source.
+	Original bool
+	Source   []int
+}
+
+// NewNumbering object starting with the number start.
+func NewNumbering(start int) *Numbering {
+	return &Numbering{Start: start, Title: "#"}
+}
+
+// cell returns the content of the number column for row i.
+// The title is placed into the first header row.
+func (n *Numbering) cell(i, headerRows, width int) string {
+	switch {
+	case i == 0 && headerRows > 0:
+		return n.Title
+	case i < headerRows:
+		return ""
+	}
+	return fmt.Sprintf("%*d", width, n.number(i-headerRows))
+}
+
+// number of body row i.
+func (n *Numbering) number(i int) int {
+	if n.Original && i < len(n.Source) {
+		return n.Start + n.Source[i]
+	}
+	return n.Start + i
+}
+
+// width of the number column. The count of body rows is -1 if unknown.
+func (n *Numbering) width(bodyRows int) int {
+	w := n.Width
+	if bodyRows >= 0 {
+		last := n.Start + bodyRows - 1
+		if l := len(strconv.Itoa(last)); l > w {
+			w = l
+		}
+	}
+	if n.Original {
+		for _, i := range n.Source {
+			if l := len(strconv.Itoa(n.Start + i)); l > w {
+				w = l
+			}
+		}
+	}
+	if l := len(strconv.Itoa(n.Start)); l > w {
+		w = l
+	}
+	return w
+}
+
+// prepend the number column to c and move the spans one column right.
+// The title is merged with the empty cells below it.
+func (n *Numbering) prepend(c row.ColumnCap, s row.Spans, width, headerRows int,
+	p row.Padding) (row.ColumnCap, row.Spans) {
+	title := len([]rune(n.Title))
+	if title > width {
+		width = title
+	}
+	c = append(row.ColumnCap{width + p.Left + p.Right}, c...)
+	moved := make(row.Spans, len(s))
+	for i, sp := range s {
+		sp.Col++
+		moved[i] = sp
+	}
+	if headerRows > 1 {
+		moved = append(moved, row.Span{Row: 0, Col: 0, Rows: headerRows, Cols: 1})
+	}
+	return c, moved
+}
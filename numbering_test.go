@@ -0,0 +1,83 @@
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNumbering_cell(t *testing.T) {
+	n := NewNumbering(1)
+	if s := n.cell(0, 1, 2); s != "#" {
+		t.Errorf("should be %q but is %q", "#", s)
+	}
+	if s := n.cell(1, 1, 2); s != " 1" {
+		t.Errorf("should be %q but is %q", " 1", s)
+	}
+	if s := n.cell(1, 2, 2); s != "" {
+		t.Errorf("should be %q but is %q", "", s)
+	}
+}
+
+func TestNumbering_width(t *testing.T) {
+	n := &Numbering{Start: 5, Width: 1}
+	if w := n.width(5); w != 1 {
+		t.Errorf("should be %d but is %d", 1, w)
+	}
+	if w := n.width(6); w != 2 {
+		t.Errorf("should be %d but is %d", 2, w)
+	}
+	if w := n.width(-1); w != 1 {
+		t.Errorf("should be %d but is %d", 1, w)
+	}
+}
+
+func TestNumbering_table(t *testing.T) {
+	ta, _ := New(true, [][]string{
+		{"Name", "Size"},
+		{"foo", "1"},
+		{"bar", "2"}}...)
+	ta.Numbering = NewNumbering(0)
+	s := ta.String()
+	exp := "# Name Size \n============\n0 foo  1    \n1 bar  2    "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestNumbering_headStyle(t *testing.T) {
+	ta, _ := New(false, [][]string{
+		{"foo", "1"},
+		{"bar", "2"}}...)
+	ta.Numbering = &Numbering{Start: 9}
+	ta.HeadStyle = NewStyle('+', '=', 'I', true)
+	ta.BodyStyle = NewStyle('+', '-', '|', true)
+	s := ta.String()
+	exp := "I 9 Ifoo |1 |\n+---+----+--+\nI10 Ibar |2 |"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestNumbering_readFrom(t *testing.T) {
+	text := "a\nb\n"
+	ta, _ := ReadFrom(strings.NewReader(text), false, []int{1})
+	ta.Numbering = &Numbering{Start: 1, Width: 3}
+	s := ta.String()
+	exp := "  1 a \n  2 b "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestNumbering_original(t *testing.T) {
+	ta, _ := New(true, [][]string{
+		{"Name"},
+		{"c"},
+		{"a"}}...)
+	ta.Numbering = &Numbering{Start: 1, Title: "#", Original: true, Source: []int{11, 0}}
+	s := ta.String()
+	exp := "#  Name \n========\n12 c    \n 1 a    "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
@@ -0,0 +1,185 @@
+package table
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ODS renders the model as OpenDocument spreadsheet, like XLSX. The
+// header rows are frozen by the view settings of the document.
+type ODS struct {
+	Aggregates []string // functions of the footer numbers, see XLSX
+}
+
+// Render the model m.
+func (o ODS) Render(w io.Writer, m *Model) error {
+	if err := checkAggregates(o.Aggregates); err != nil {
+		return err
+	}
+	name := xmlEscape(sheetName(m.Title))
+	return writeZip(w, []zipFile{
+		{"mimetype", odsMimeType},
+		{"META-INF/manifest.xml", odsManifest},
+		{"content.xml", odsContent(m, name, o.Aggregates)},
+		{"settings.xml", odsSettingsOf(name, len(m.Header))},
+	})
+}
+
+// odsContent returns the content of the document with the table name.
+func odsContent(m *Model, name string, aggregates []string) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<office:document-content ` + odsNamespaces + ` office:version="1.2">`)
+	b.WriteString("<office:automatic-styles>")
+	for j, c := range m.Columns {
+		fmt.Fprintf(&b, `<style:style style:name="co%d" style:family="table-column">`+
+			`<style:table-column-properties style:column-width="%.2fcm"/></style:style>`, j+1, float64(c.Width+2)*0.2)
+	}
+	b.WriteString(odsStyles)
+	b.WriteString("</office:automatic-styles>")
+	fmt.Fprintf(&b, `<office:body><office:spreadsheet><table:table table:name="%s">`, name)
+	for j := range m.Columns {
+		fmt.Fprintf(&b, `<table:table-column table:style-name="co%d"/>`, j+1)
+	}
+	head, body := len(m.Header), len(m.Body)
+	for i, cells := range m.rows() {
+		if i == 0 && head > 0 {
+			b.WriteString("<table:table-header-rows>")
+		}
+		b.WriteString("<table:table-row>")
+		for j, c := range cells {
+			odsCell(&b, m, c, j, i < head, i >= head+body, aggregates)
+		}
+		b.WriteString("</table:table-row>")
+		if i == head-1 {
+			b.WriteString("</table:table-header-rows>")
+		}
+	}
+	if m.Caption != "" {
+		fmt.Fprintf(&b, `<table:table-row><table:table-cell/></table:table-row>`+
+			`<table:table-row><table:table-cell office:value-type="string"><text:p>%s</text:p>`+
+			`</table:table-cell></table:table-row>`, xmlEscape(m.Caption))
+	}
+	b.WriteString("</table:table></office:spreadsheet></office:body></office:document-content>")
+	return b.String()
+}
+
+// odsCell writes cell c of column j.
+func odsCell(b *strings.Builder, m *Model, c Cell, j int, head, foot bool, aggregates []string) {
+	if c.Merged {
+		b.WriteString("<table:covered-table-cell/>")
+		return
+	}
+	kind, t := sheetValue(c.Text)
+	if head || c.Link != "" {
+		kind = sheetString
+	}
+	var attrs string
+	switch {
+	case head:
+		attrs += ` table:style-name="head"`
+	case kind == sheetDate:
+		attrs += ` table:style-name="date"`
+	case kind == sheetDateTime:
+		attrs += ` table:style-name="datetime"`
+	case foot:
+		attrs += ` table:style-name="foot"`
+	}
+	if c.Rows > 1 || c.Cols > 1 {
+		attrs += fmt.Sprintf(` table:number-columns-spanned="%d" table:number-rows-spanned="%d"`, c.Cols, c.Rows)
+	}
+	text := xmlEscape(c.Text)
+	switch {
+	case c.Text == "":
+	case c.Link != "":
+		attrs += ` office:value-type="string"`
+		text = fmt.Sprintf(`<text:a xlink:type="simple" xlink:href="%s">%s</text:a>`, xmlEscape(c.Link), text)
+	case kind == sheetNumber:
+		if a := odsAggregate(m, j, c.Text, foot, aggregates); a != "" {
+			attrs += ` table:formula="` + a + `"`
+		}
+		attrs += fmt.Sprintf(` office:value-type="float" office:value="%s"`, c.Text)
+	case kind == sheetDate:
+		attrs += fmt.Sprintf(` office:value-type="date" office:date-value="%s"`, t.Format("2006-01-02"))
+	case kind == sheetDateTime:
+		attrs += fmt.Sprintf(` office:value-type="date" office:date-value="%s"`, t.Format("2006-01-02T15:04:05"))
+	default:
+		attrs += ` office:value-type="string"`
+	}
+	if c.Text == "" {
+		fmt.Fprintf(b, `<table:table-cell%s/>`, attrs)
+		return
+	}
+	fmt.Fprintf(b, `<table:table-cell%s><text:p>%s</text:p></table:table-cell>`, attrs, text)
+}
+
+// odsAggregate returns the formula of a footer number, see sheetFormula.
+func odsAggregate(m *Model, j int, text string, foot bool, aggregates []string) string {
+	if !foot {
+		return ""
+	}
+	a := sheetFormula(m, j, text, aggregates)
+	if a == "" {
+		return ""
+	}
+	col := columnName(j)
+	return fmt.Sprintf("of:=%s([.%s%d:.%s%d])", a, col, len(m.Header)+1, col, len(m.Header)+len(m.Body))
+}
+
+const odsMimeType = "application/vnd.oasis.opendocument.spreadsheet"
+
+const odsNamespaces = `xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" ` +
+	`xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0" ` +
+	`xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" ` +
+	`xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" ` +
+	`xmlns:fo="urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0" ` +
+	`xmlns:number="urn:oasis:names:tc:opendocument:xmlns:datastyle:1.0" ` +
+	`xmlns:of="urn:oasis:names:tc:opendocument:xmlns:of:1.2" ` +
+	`xmlns:xlink="http://www.w3.org/1999/xlink"`
+
+// odsStyles are the cell styles head, foot, date and datetime.
+const odsStyles = `<number:date-style style:name="N1"><number:year number:style="long"/><number:text>-</number:text>` +
+	`<number:month number:style="long"/><number:text>-</number:text><number:day number:style="long"/></number:date-style>` +
+	`<number:date-style style:name="N2"><number:year number:style="long"/><number:text>-</number:text>` +
+	`<number:month number:style="long"/><number:text>-</number:text><number:day number:style="long"/>` +
+	`<number:text> </number:text><number:hours number:style="long"/><number:text>:</number:text>` +
+	`<number:minutes number:style="long"/><number:text>:</number:text><number:seconds number:style="long"/></number:date-style>` +
+	`<style:style style:name="head" style:family="table-cell">` +
+	`<style:table-cell-properties fo:background-color="#d9d9d9" fo:border-bottom="0.5pt solid #000000"/>` +
+	`<style:text-properties fo:font-weight="bold"/></style:style>` +
+	`<style:style style:name="foot" style:family="table-cell"><style:text-properties fo:font-weight="bold"/></style:style>` +
+	`<style:style style:name="date" style:family="table-cell" style:data-style-name="N1"/>` +
+	`<style:style style:name="datetime" style:family="table-cell" style:data-style-name="N2"/>`
+
+const odsManifest = xml.Header + `<manifest:manifest xmlns:manifest="urn:oasis:names:tc:opendocument:xmlns:manifest:1.0" manifest:version="1.2">` +
+	`<manifest:file-entry manifest:full-path="/" manifest:version="1.2" manifest:media-type="` + odsMimeType + `"/>` +
+	`<manifest:file-entry manifest:full-path="content.xml" manifest:media-type="text/xml"/>` +
+	`<manifest:file-entry manifest:full-path="settings.xml" manifest:media-type="text/xml"/>` +
+	`</manifest:manifest>`
+
+// odsSettingsOf returns the view settings, which freeze the first rows
+// of the table.
+func odsSettingsOf(name string, rows int) string {
+	mode := 0
+	if rows > 0 {
+		mode = 2
+	}
+	return fmt.Sprintf(odsSettings, name, mode, rows)
+}
+
+// odsSettings is formatted with the table name, the split mode and the
+// number of frozen rows.
+const odsSettings = xml.Header + `<office:document-settings xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" ` +
+	`xmlns:config="urn:oasis:names:tc:opendocument:xmlns:config:1.0" office:version="1.2"><office:settings>` +
+	`<config:config-item-set config:name="ooo:view-settings"><config:config-item-map-indexed config:name="Views">` +
+	`<config:config-item-map-entry><config:config-item config:name="ViewId" config:type="string">view1</config:config-item>` +
+	`<config:config-item-map-named config:name="Tables"><config:config-item-map-entry config:name="%s">` +
+	`<config:config-item config:name="VerticalSplitMode" config:type="short">%d</config:config-item>` +
+	`<config:config-item config:name="VerticalSplitPosition" config:type="int">%d</config:config-item>` +
+	`<config:config-item config:name="ActiveSplitRange" config:type="short">2</config:config-item>` +
+	`<config:config-item config:name="PositionTop" config:type="int">0</config:config-item>` +
+	`<config:config-item config:name="PositionBottom" config:type="int">%[3]d</config:config-item>` +
+	`</config:config-item-map-entry></config:config-item-map-named></config:config-item-map-entry>` +
+	`</config:config-item-map-indexed></config:config-item-set></office:settings></office:document-settings>`
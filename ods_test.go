@@ -0,0 +1,59 @@
+package table
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestODS(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := newSheetTable(ODS{}).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+	z, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := z.File[0]; f.Name != "mimetype" || f.Method != zip.Store {
+		t.Errorf("the first file should be the stored mimetype, is %s", f.Name)
+	}
+	names, files := readZip(t, b)
+	if files["mimetype"] != odsMimeType || len(names) != 4 {
+		t.Errorf("wrong files: %v", names)
+	}
+	content := files["content.xml"]
+	for _, exp := range []string{
+		`<table:table table:name="Q1 sales">`,
+		`<table:table-header-rows><table:table-row><table:table-cell table:style-name="head" office:value-type="string"><text:p>Name</text:p>`,
+		`<text:a xlink:type="simple" xlink:href="http://x">a&amp;b</text:a>`,
+		`<table:table-cell table:style-name="date" office:value-type="date" office:date-value="2024-01-02"><text:p>2024-01-02</text:p>`,
+		`office:date-value="2024-02-03T12:00:00"`,
+		`<table:table-cell table:style-name="foot" table:formula="of:=SUM([.C2:.C3])" office:value-type="float" office:value="7">`,
+		`style:column-width="1.40cm"`,
+	} {
+		if !strings.Contains(content, exp) {
+			t.Errorf("content should contain %s:\n%s", exp, content)
+		}
+	}
+	if s := files["settings.xml"]; !strings.Contains(s, `config:name="VerticalSplitPosition" config:type="int">1<`) {
+		t.Errorf("the header row should be frozen:\n%s", s)
+	}
+}
+
+func TestODS_spans(t *testing.T) {
+	ta, _ := New(false, [][]string{{"a", "b"}, {"c", "d"}}...)
+	ta.Span(0, 0, 1, 2)
+	m, _ := ta.Model()
+	content := odsContent(m, "t", nil)
+	exp := `<table:table-row><table:table-cell table:number-columns-spanned="2" table:number-rows-spanned="1" ` +
+		`office:value-type="string"><text:p>a</text:p></table:table-cell><table:covered-table-cell/></table:table-row>`
+	if !strings.Contains(content, exp) {
+		t.Errorf("content should contain %s:\n%s", exp, content)
+	}
+	if strings.Contains(content, "header-rows") {
+		t.Errorf("a table without header should have no header rows:\n%s", content)
+	}
+}
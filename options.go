@@ -0,0 +1,277 @@
+package table
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/thibran/table/row"
+)
+
+// Option configures a Table created by NewWith, ReadWith or ReadXLSX.
+type Option func(*options) error
+
+// options collects the settings, which are validated together before
+// the table is created.
+type options struct {
+	t         *Table // table with the settings of the options
+	header    int
+	headerSet bool // if false, ReadXLSX detects the header rows
+	widths    []int
+	comma     rune
+
+	sheet      string // name of the sheet read by ReadXLSX
+	sheetIndex int
+	sheetSet   bool
+}
+
+var errNoWidths = errors.New("ReadWith needs the column widths, use WithWidths")
+var errComma = errors.New("WithComma only applies to ReadWith")
+
+// WithHeader treats the first rows rows as header.
+func WithHeader(rows int) Option {
+	return func(o *options) error {
+		if rows < 0 {
+			return fmt.Errorf("invalid number of header rows %d", rows)
+		}
+		o.header, o.headerSet = rows, true
+		return nil
+	}
+}
+
+// WithStyle sets the style of the header and the body, nil keeps the
+// default style.
+func WithStyle(head, body *Style) Option {
+	return func(o *options) error {
+		if head != nil {
+			o.t.HeadStyle = head
+		}
+		if body != nil {
+			o.t.BodyStyle = body
+		}
+		return nil
+	}
+}
+
+// WithWidths sets the width of the columns in runes, without padding.
+// A width of 0 uses the width of the widest cell of the column, or one
+// rune for ReadWith, which needs the widths of all columns.
+func WithWidths(widths ...int) Option {
+	return func(o *options) error {
+		for j, w := range widths {
+			if w < 0 {
+				return fmt.Errorf("invalid width %d of column %d", w, j)
+			}
+		}
+		o.widths = widths
+		return nil
+	}
+}
+
+// WithAlignment sets the alignment per column, see Table.Align.
+func WithAlignment(a ...row.Align) Option {
+	return func(o *options) error {
+		for j := range a {
+			if a[j] < row.AlignLeft || a[j] > row.AlignCenter {
+				return fmt.Errorf("invalid alignment %d of column %d", a[j], j)
+			}
+		}
+		o.t.Align = a
+		return nil
+	}
+}
+
+// WithMaxWidth splits tables wider than width runes into column chunks,
+// which repeat the first keyColumns columns.
+func WithMaxWidth(width, keyColumns int) Option {
+	return func(o *options) error {
+		if width < 1 || keyColumns < 0 {
+			return fmt.Errorf("invalid max width %d with %d key columns",
+				width, keyColumns)
+		}
+		o.t.MaxWidth = width
+		o.t.KeyColumns = keyColumns
+		return nil
+	}
+}
+
+// WithFormat adds conditional formatting rules, see Table.Rules.
+func WithFormat(rules ...row.Rule) Option {
+	return func(o *options) error {
+		for i, r := range rules {
+			if r.Match == nil {
+				return fmt.Errorf("format rule %d has no Match function", i)
+			}
+		}
+		o.t.Rules = append(o.t.Rules, rules...)
+		return nil
+	}
+}
+
+// WithPadding sets the padding per column, see Table.Padding.
+func WithPadding(p ...row.Padding) Option {
+	return func(o *options) error {
+		for j := range p {
+			if p[j].Left < 0 || p[j].Right < 0 {
+				return fmt.Errorf("invalid padding %v of column %d", p[j], j)
+			}
+		}
+		o.t.Padding = p
+		return nil
+	}
+}
+
+// WithTruncate sets the truncation per column, see Table.Truncate.
+func WithTruncate(tr ...row.Truncate) Option {
+	return func(o *options) error {
+		for j := range tr {
+			if tr[j].Mode < row.TruncateEnd || tr[j].Mode > row.TruncateMiddle {
+				return fmt.Errorf("invalid truncate mode %d of column %d",
+					tr[j].Mode, j)
+			}
+		}
+		o.t.Truncate = tr
+		return nil
+	}
+}
+
+// WithTitle sets the title above and the caption below the table.
+func WithTitle(title, caption string) Option {
+	return func(o *options) error {
+		o.t.Title = title
+		o.t.Caption = caption
+		return nil
+	}
+}
+
+// WithNumbering adds a column with row numbers starting at start.
+func WithNumbering(start int) Option {
+	return func(o *options) error {
+		o.t.Numbering = NewNumbering(start)
+		return nil
+	}
+}
+
+// WithRenderer writes the table with r instead of drawing it as text.
+func WithRenderer(r Renderer) Option {
+	return func(o *options) error {
+		o.t.Renderer = r
+		return nil
+	}
+}
+
+// WithComma sets the field delimiter of the CSV read by ReadWith.
+func WithComma(r rune) Option {
+	return func(o *options) error {
+		if r == 0 || r == '"' || r == '\r' || r == '\n' {
+			return fmt.Errorf("invalid delimiter %q", r)
+		}
+		o.comma = r
+		return nil
+	}
+}
+
+// NewWith creates a table of rows configured by opts.
+func NewWith(rows [][]string, opts ...Option) (*Table, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	columns := len(row.NewColumnCap(rows, 0))
+	switch {
+	case o.comma != 0:
+		return nil, errComma
+	case o.sheetSet:
+		return nil, errSheet
+	case o.header > len(rows):
+		return nil, fmt.Errorf("%d header rows, but the table has only %d rows",
+			o.header, len(rows))
+	}
+	if err := o.validate(columns); err != nil {
+		return nil, err
+	}
+	return o.newTable(rows), nil
+}
+
+// ReadWith creates a table reading CSV from r until io.EOF, configured by
+// opts. The widths of the columns must be set with WithWidths.
+func ReadWith(r io.Reader, opts ...Option) (*Table, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(o.widths) == 0:
+		return nil, errNoWidths
+	case o.sheetSet:
+		return nil, errSheet
+	}
+	if err := o.validate(len(o.widths)); err != nil {
+		return nil, err
+	}
+	return o.readTable(r), nil
+}
+
+func newOptions(opts []Option) (*options, error) {
+	o := &options{t: newTable()}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// validate the combination of the options for a table with the given
+// number of columns.
+func (o *options) validate(columns int) error {
+	t := o.t
+	switch {
+	case len(o.widths) > columns:
+		return fmt.Errorf("%d widths, but the table has only %d columns",
+			len(o.widths), columns)
+	case len(t.Align) > columns:
+		return fmt.Errorf("%d alignments, but the table has only %d columns",
+			len(t.Align), columns)
+	case t.MaxWidth > 0 && t.KeyColumns >= columns:
+		return fmt.Errorf("%d key columns leave no column of %d to split",
+			t.KeyColumns, columns)
+	}
+	for _, r := range t.Rules {
+		if r.Column >= columns {
+			return fmt.Errorf("format rule for column %d, but the table has only %d columns",
+				r.Column, columns)
+		}
+	}
+	return nil
+}
+
+// newTable creates the table of rows, see New.
+func (o *options) newTable(rows [][]string) *Table {
+	t := o.t
+	t.r = newReader(rows)
+	t.rows = rows
+	t.HeaderRows = o.header
+	t.columnCap = row.NewColumnCap(rows, 0)
+	for j, w := range o.widths {
+		if w > 0 {
+			t.columnCap[j] = w
+		}
+		t.fixed = append(t.fixed, w > 0)
+	}
+	return t
+}
+
+// readTable creates the table reading r, see ReadFrom.
+func (o *options) readTable(r io.Reader) *Table {
+	t := o.t
+	cr := csv.NewReader(r)
+	if o.comma != 0 {
+		cr.Comma = o.comma
+	}
+	t.r = cr
+	t.HeaderRows = o.header
+	t.columnCap = row.ConvRunesPerColumn(o.widths, 0)
+	return t
+}
@@ -0,0 +1,108 @@
+package table
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thibran/table/row"
+)
+
+func TestNewWith(t *testing.T) {
+	ta, err := NewWith([][]string{
+		{"Fruit", "Count"},
+		{"Apple", "4"},
+		{"Banana", "25"}},
+		WithHeader(1),
+		WithStyle(StyleBoring(), nil),
+		WithWidths(3),
+		WithAlignment(row.AlignLeft, row.AlignRight))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := ta.String()
+	exp := "Fru Count \n==========\nApp     4 \nBan    25 "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestNewWith_widthsSpans(t *testing.T) {
+	ta, err := NewWith([][]string{
+		{"Name", "Latency", "Latency"},
+		{"", "p50", "p99"},
+		{"a", "1", "95"}},
+		WithHeader(2),
+		WithWidths(6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := ta.String()
+	exp := "Name   Latency \n       ========\n       p50 p99 \n===============\na      1   95  "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestReadWith(t *testing.T) {
+	ta, err := ReadWith(strings.NewReader("a;b\nc;d\n"),
+		WithComma(';'), WithWidths(1, 1), WithTitle("T", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := ta.String()
+	exp := " T  \na b \nc d "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestNewWith_errors(t *testing.T) {
+	rows := [][]string{{"a", "b"}}
+	tests := [][]Option{
+		{WithHeader(-1)},
+		{WithHeader(2)},
+		{WithWidths(1, -1)},
+		{WithWidths(1, 1, 1)},
+		{WithAlignment(row.AlignLeft, row.AlignLeft, row.AlignLeft)},
+		{WithAlignment(row.Align(7))},
+		{WithMaxWidth(0, 0)},
+		{WithMaxWidth(10, 2)},
+		{WithFormat(row.Rule{Column: 0})},
+		{WithFormat(row.Rule{Column: 2, Match: row.Empty})},
+		{WithPadding(row.Padding{Left: -1})},
+		{WithTruncate(row.Truncate{Mode: row.TruncateMode(9)})},
+		{WithComma(';')},
+	}
+	for i, opts := range tests {
+		if _, err := NewWith(rows, opts...); err == nil {
+			t.Errorf("options %d should fail", i)
+		}
+	}
+}
+
+func TestReadWith_errors(t *testing.T) {
+	if _, err := ReadWith(strings.NewReader("")); err != errNoWidths {
+		t.Errorf("should be %v but is %v", errNoWidths, err)
+	}
+	if _, err := ReadWith(strings.NewReader(""), WithComma('\n')); err == nil {
+		t.Errorf("newline should be an invalid delimiter")
+	}
+}
+
+func TestNewWith_options(t *testing.T) {
+	ta, err := NewWith([][]string{{"a", "b"}},
+		WithMaxWidth(80, 1),
+		WithFormat(row.Rule{Column: -1, Match: row.Empty}),
+		WithPadding(row.Padding{Left: 1}),
+		WithTruncate(row.Truncate{Mode: row.TruncateMiddle}),
+		WithNumbering(0),
+		WithRenderer(Markdown{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ta.MaxWidth != 80 || ta.KeyColumns != 1 || len(ta.Rules) != 1 ||
+		ta.Padding[0].Left != 1 || ta.Truncate[0].Mode != row.TruncateMiddle ||
+		ta.Numbering.Start != 0 || ta.Renderer != (Markdown{}) {
+		t.Errorf("options not applied: %+v", ta)
+	}
+}
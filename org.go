@@ -0,0 +1,90 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Org renders the model as Org-mode table. All header rows are written
+// above the |-+-| separator, the footer rows are separated from the body
+// by another one. Org tables can not merge cells, merged cells are empty.
+// The title is written as #+CAPTION, the caption as paragraph below the
+// table.
+type Org struct{}
+
+// Render the model m.
+func (Org) Render(w io.Writer, m *Model) error {
+	texts := make([][]string, 0, len(m.Header)+len(m.Body)+len(m.Footer))
+	for _, cells := range m.rows() {
+		r := make([]string, len(cells))
+		for j, c := range cells {
+			if !c.Merged {
+				r[j] = orgText(c)
+			}
+		}
+		texts = append(texts, r)
+	}
+	width := make([]int, len(m.Columns))
+	for j := range width {
+		width[j] = 1
+		for _, r := range texts {
+			if n := utf8.RuneCountInString(r[j]); n > width[j] {
+				width[j] = n
+			}
+		}
+	}
+	var b strings.Builder
+	if m.Title != "" {
+		fmt.Fprintf(&b, "#+CAPTION: %s\n", orgEscape(m.Title))
+	}
+	footer := len(texts) - len(m.Footer)
+	for i, r := range texts {
+		if i > 0 && (i == len(m.Header) || i == footer) {
+			orgRule(&b, width)
+		}
+		b.WriteString("|")
+		for j, s := range r {
+			b.WriteString(" " + m.Columns[j].Align.Pad(s, width[j]) + " |")
+		}
+		b.WriteString("\n")
+	}
+	if m.Caption != "" {
+		fmt.Fprintf(&b, "\n%s\n", orgEscape(m.Caption))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// orgRule writes a |-+-| separator line.
+func orgRule(b *strings.Builder, width []int) {
+	parts := make([]string, len(width))
+	for j, n := range width {
+		parts[j] = strings.Repeat("-", n+2)
+	}
+	b.WriteString("|" + strings.Join(parts, "+") + "|\n")
+}
+
+// orgText returns the escaped text of c, links are written as [[url][text]].
+func orgText(c Cell) string {
+	s := orgEscape(c.Text)
+	if c.Link == "" {
+		return s
+	}
+	if s == "" {
+		return "[[" + c.Link + "]]"
+	}
+	return "[[" + c.Link + "][" + s + "]]"
+}
+
+var orgReplacer = strings.NewReplacer(
+	`|`, `\vert{}`,
+	"\r\n", " ",
+	"\n", " ",
+)
+
+// orgEscape replaces the column separator and line breaks in s.
+func orgEscape(s string) string {
+	return orgReplacer.Replace(s)
+}
@@ -0,0 +1,31 @@
+package table
+
+import "testing"
+
+func TestOrg(t *testing.T) {
+	ta := newModelTable()
+	ta.Caption = "c"
+	ta.Renderer = Org{}
+	exp := `#+CAPTION: T
+| Name            | Latency |     |
+|                 |     p50 | p99 |
+|-----------------+---------+-----|
+| [[http://a][a]] |       1 |  95 |
+|-----------------+---------+-----|
+| Total           |       1 |  95 |
+
+c
+`
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestOrg_escape(t *testing.T) {
+	ta, _ := New(false, [][]string{{"a|b", "x"}, {"", "y\nz"}}...)
+	ta.Renderer = Org{}
+	exp := "| a\\vert{}b | x   |\n|           | y z |\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
@@ -0,0 +1,61 @@
+package table
+
+import (
+	"fmt"
+	"io"
+)
+
+// Pager splits a table into pages of Rows body rows. Every page starts
+// with the title and the header rows and ends with the bottom line and
+// a footer with the page number.
+type Pager struct {
+	Rows int // body rows per page
+
+	// Footer returns the footer of a page, pages is 0 if the number of
+	// pages is unknown. If nil, "Page n of m" is written.
+	Footer func(page, pages int) string
+
+	// Separator is written between two pages, e.g. "\f" for printers.
+	// The default is an empty line.
+	Separator string
+}
+
+// WritePages writes the table split into pages and returns the bytes written.
+func (t *Table) WritePages(w io.Writer, p Pager) (int64, error) {
+	t.rewind()
+	d := t.newDraw(w)
+	return t.draw(d, &p)
+}
+
+// isBreak returns true, if a new page starts with body row i.
+func (p *Pager) isBreak(i int) bool {
+	return p != nil && p.Rows > 0 && i > 0 && i%p.Rows == 0
+}
+
+// count returns the number of pages or 0 if bodyRows is unknown (-1).
+func (p *Pager) count(bodyRows int) int {
+	if p == nil || p.Rows <= 0 || bodyRows < 0 {
+		return 0
+	}
+	if bodyRows == 0 {
+		return 1
+	}
+	return (bodyRows + p.Rows - 1) / p.Rows
+}
+
+func (p *Pager) footer(page, pages int) string {
+	switch {
+	case p.Footer != nil:
+		return p.Footer(page, pages)
+	case pages == 0:
+		return fmt.Sprintf("Page %d", page)
+	}
+	return fmt.Sprintf("Page %d of %d", page, pages)
+}
+
+func (p *Pager) separator() string {
+	if p.Separator == "" {
+		return "\n"
+	}
+	return p.Separator
+}
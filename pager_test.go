@@ -0,0 +1,68 @@
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestWritePages(t *testing.T) {
+	ta, _ := New(true, [][]string{
+		{"h"},
+		{"a"},
+		{"b"},
+		{"c"}}...)
+	var b bytes.Buffer
+	n, err := ta.WritePages(&b, Pager{Rows: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := b.String()
+	exp := "h \n==\na \nb \nPage 1 of 2\n\nh \n==\nc \nPage 2 of 2"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+	if n != int64(len(exp)) {
+		t.Errorf("written bytes should be %d but are %d", len(exp), n)
+	}
+}
+
+func TestWritePages_unknownCount(t *testing.T) {
+	ta, _ := ReadFrom(strings.NewReader("a\nb\n"), false, []int{1})
+	var b bytes.Buffer
+	ta.WritePages(&b, Pager{
+		Rows:      1,
+		Separator: "\f",
+		Footer: func(page, pages int) string {
+			return fmt.Sprintf("-%d/%d-", page, pages)
+		},
+	})
+	s := b.String()
+	exp := "a \n-1/0-\n\fb \n-2/0-"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestWritePages_lineEvery(t *testing.T) {
+	ta, _ := New(true, [][]string{{"h"}, {"a"}, {"b"}, {"c"}, {"d"}, {"e"}}...)
+	ta.LineEvery = 2
+	var b bytes.Buffer
+	ta.WritePages(&b, Pager{Rows: 3})
+	s := b.String()
+	exp := "h \n==\na \nb \n  \nc \nPage 1 of 2\n\nh \n==\nd \ne \nPage 2 of 2"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestPager_count(t *testing.T) {
+	p := &Pager{Rows: 10}
+	tests := []struct{ rows, exp int }{{-1, 0}, {0, 1}, {10, 1}, {11, 2}}
+	for _, tt := range tests {
+		if n := p.count(tt.rows); n != tt.exp {
+			t.Errorf("count(%d) should be %d but is %d", tt.rows, tt.exp, n)
+		}
+	}
+}
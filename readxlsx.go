@@ -0,0 +1,564 @@
+package table
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thibran/table/row"
+)
+
+var errSheet = errors.New("WithSheet and WithSheetIndex only apply to ReadXLSX")
+
+// WithSheet selects the sheet read by ReadXLSX by its name.
+func WithSheet(name string) Option {
+	return func(o *options) error {
+		o.sheet, o.sheetSet = name, true
+		return nil
+	}
+}
+
+// WithSheetIndex selects the sheet read by ReadXLSX by its index, 0 is
+// the first sheet of the workbook.
+func WithSheetIndex(i int) Option {
+	return func(o *options) error {
+		if i < 0 {
+			return fmt.Errorf("invalid sheet index %d", i)
+		}
+		o.sheet, o.sheetIndex, o.sheetSet = "", i, true
+		return nil
+	}
+}
+
+// ReadXLSX creates a table from a sheet of the XLSX workbook read from r
+// until io.EOF, configured by opts. The first sheet is read, unless one
+// is selected with WithSheet or WithSheetIndex. The table covers the used
+// range of the sheet, merged cells are spanned and hyperlinks linked. Merged
+// cells reaching from the header into the body are cut below the header.
+//
+// Numbers are read as their shortest text, dates as 2006-01-02 or
+// 2006-01-02 15:04:05 and booleans as true or false, which the renderers
+// write as typed values again. Without WithHeader, the leading bold rows
+// are the header, or the first row if it has only texts and the rows
+// below have numbers or dates.
+func ReadXLSX(r io.Reader, opts ...Option) (*Table, error) {
+	o, err := newOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	if o.comma != 0 {
+		return nil, errComma
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	z, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		return nil, err
+	}
+	s, err := readSheet(z, o.sheet, o.sheetIndex)
+	if err != nil {
+		return nil, err
+	}
+	if !o.headerSet {
+		o.header = s.headerRows()
+	}
+	if o.header > len(s.rows) {
+		return nil, fmt.Errorf("%d header rows, but the sheet has only %d rows",
+			o.header, len(s.rows))
+	}
+	var columns int
+	if len(s.rows) > 0 {
+		columns = len(s.rows[0])
+	} else {
+		s.rows = [][]string{}
+	}
+	if err := o.validate(columns); err != nil {
+		return nil, err
+	}
+	t := o.newTable(s.rows)
+	for _, sp := range s.spans {
+		// merged cells crossing the header are cut below the header
+		if sp.Row < o.header && sp.Row+sp.Rows > o.header {
+			sp.Rows = o.header - sp.Row
+			if sp.Rows == 1 && sp.Cols == 1 {
+				continue
+			}
+		}
+		if err := t.Span(sp.Row, sp.Col, sp.Rows, sp.Cols); err != nil {
+			return nil, fmt.Errorf("merged cells at %s%d: %v", columnName(sp.Col+s.col0),
+				sp.Row+s.row0+1, err)
+		}
+	}
+	for p, url := range s.links {
+		t.Link(p.i, p.j, url)
+	}
+	return t, nil
+}
+
+// xlsxCellKind is the type of a cell read from a sheet.
+type xlsxCellKind int
+
+const (
+	xlsxEmpty xlsxCellKind = iota
+	xlsxText
+	xlsxTyped // number, date or boolean
+)
+
+// sheet is the used range of a worksheet.
+type sheet struct {
+	rows       [][]string
+	kinds      [][]xlsxCellKind
+	bold       [][]bool
+	row0, col0 int        // first row and column of the used range
+	spans      []row.Span // merged cells, relative to the used range
+	links      map[pos]string
+}
+
+// headerRows returns the number of leading rows, which are bold texts
+// and not the whole sheet. If there are none, it is 1 if the first row
+// has only texts and a row below a number, date or boolean.
+func (s *sheet) headerRows() int {
+	n := 0
+	for n < len(s.rows)-1 && s.isHeader(n, true) {
+		n++
+	}
+	if n > 0 || len(s.rows) < 2 || !s.isHeader(0, false) {
+		return n
+	}
+	for _, kinds := range s.kinds[1:] {
+		for _, k := range kinds {
+			if k == xlsxTyped {
+				return 1
+			}
+		}
+	}
+	return 0
+}
+
+// isHeader returns true if row i has texts only, which are bold if bold
+// is true.
+func (s *sheet) isHeader(i int, bold bool) bool {
+	var texts int
+	for j, k := range s.kinds[i] {
+		switch {
+		case k == xlsxTyped:
+			return false
+		case k == xlsxText && bold && !s.bold[i][j]:
+			return false
+		case k == xlsxText:
+			texts++
+		}
+	}
+	return texts > 0
+}
+
+// XML of the parts of a workbook, reduced to the read elements.
+type (
+	xlsxRelsXML struct {
+		Rels []struct {
+			ID     string `xml:"Id,attr"`
+			Type   string `xml:"Type,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	xlsxWorkbookXML struct {
+		Props struct {
+			Date1904 string `xml:"date1904,attr"`
+		} `xml:"workbookPr"`
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			ID   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	xlsxTextXML struct {
+		T    string `xml:"t"`
+		Runs []struct {
+			T string `xml:"t"`
+		} `xml:"r"`
+	}
+	xlsxStylesXML struct {
+		NumFmts []struct {
+			ID   int    `xml:"numFmtId,attr"`
+			Code string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		Fonts []struct {
+			B *struct {
+				Val string `xml:"val,attr"`
+			} `xml:"b"`
+		} `xml:"fonts>font"`
+		Xfs []struct {
+			NumFmtID int `xml:"numFmtId,attr"`
+			FontID   int `xml:"fontId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+	xlsxSheetXML struct {
+		Rows []struct {
+			R     int `xml:"r,attr"`
+			Cells []struct {
+				R  string      `xml:"r,attr"`
+				S  int         `xml:"s,attr"`
+				T  string      `xml:"t,attr"`
+				V  string      `xml:"v"`
+				F  string      `xml:"f"`
+				IS xlsxTextXML `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+		Merges []struct {
+			Ref string `xml:"ref,attr"`
+		} `xml:"mergeCells>mergeCell"`
+		Links []struct {
+			Ref string `xml:"ref,attr"`
+			ID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"hyperlinks>hyperlink"`
+	}
+)
+
+func (t xlsxTextXML) String() string {
+	s := t.T
+	for _, r := range t.Runs {
+		s += r.T
+	}
+	return s
+}
+
+// xlsxCellStyle is the style of a cell, which matters for reading it.
+type xlsxCellStyle struct {
+	date, bold bool
+}
+
+// readSheet reads the sheet with the name, or the index if name is empty.
+func readSheet(z *zip.Reader, name string, index int) (*sheet, error) {
+	files := make(map[string]*zip.File)
+	for _, f := range z.File {
+		files[f.Name] = f
+	}
+	read := func(name string, v interface{}) error {
+		f, ok := files[name]
+		if !ok {
+			return fmt.Errorf("missing %s in workbook", name)
+		}
+		r, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer r.Close()
+		if err := xml.NewDecoder(r).Decode(v); err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		return nil
+	}
+	book := "xl/workbook.xml"
+	var rels xlsxRelsXML
+	if err := read("_rels/.rels", &rels); err == nil {
+		for _, r := range rels.Rels {
+			if strings.HasSuffix(r.Type, "/officeDocument") {
+				book = partPath("", r.Target)
+			}
+		}
+	}
+	var wb xlsxWorkbookXML
+	if err := read(book, &wb); err != nil {
+		return nil, err
+	}
+	target := -1
+	for i, s := range wb.Sheets {
+		if (name != "" && s.Name == name) || (name == "" && i == index) {
+			target = i
+			break
+		}
+	}
+	switch {
+	case target < 0 && name != "":
+		return nil, fmt.Errorf("no sheet named %q", name)
+	case target < 0:
+		return nil, fmt.Errorf("no sheet %d, the workbook has %d sheets", index, len(wb.Sheets))
+	}
+	dir := path.Dir(book)
+	rels = xlsxRelsXML{}
+	if err := read(relsPath(book), &rels); err != nil {
+		return nil, err
+	}
+	var sheetPart string
+	for _, r := range rels.Rels {
+		if r.ID == wb.Sheets[target].ID {
+			sheetPart = partPath(dir, r.Target)
+		}
+	}
+	var strs []string
+	var shared struct {
+		Items []xlsxTextXML `xml:"si"`
+	}
+	for _, r := range rels.Rels {
+		if strings.HasSuffix(r.Type, "/sharedStrings") {
+			if err := read(partPath(dir, r.Target), &shared); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, si := range shared.Items {
+		strs = append(strs, si.String())
+	}
+	var styles []xlsxCellStyle
+	for _, r := range rels.Rels {
+		if strings.HasSuffix(r.Type, "/styles") {
+			var st xlsxStylesXML
+			if err := read(partPath(dir, r.Target), &st); err != nil {
+				return nil, err
+			}
+			styles = st.cellStyles()
+		}
+	}
+	var sx xlsxSheetXML
+	if err := read(sheetPart, &sx); err != nil {
+		return nil, err
+	}
+	links := make(map[string]string)
+	if len(sx.Links) > 0 {
+		// without relationships, the hyperlinks are left out
+		var srels xlsxRelsXML
+		read(relsPath(sheetPart), &srels)
+		for _, l := range sx.Links {
+			for _, r := range srels.Rels {
+				if r.ID == l.ID && l.ID != "" {
+					links[l.Ref] = r.Target
+				}
+			}
+		}
+	}
+	epoch := excelEpoch
+	if p := wb.Props.Date1904; p == "1" || p == "true" {
+		epoch = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return sx.usedRange(strs, styles, links, epoch), nil
+}
+
+// partPath returns the path of the target of a relationship of a part
+// in dir.
+func partPath(dir, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join(dir, target)
+}
+
+// relsPath returns the path of the relationships of a part.
+func relsPath(part string) string {
+	return path.Join(path.Dir(part), "_rels", path.Base(part)+".rels")
+}
+
+// cellStyles returns the styles of the cellXfs.
+func (st xlsxStylesXML) cellStyles() []xlsxCellStyle {
+	codes := make(map[int]string)
+	for _, f := range st.NumFmts {
+		codes[f.ID] = f.Code
+	}
+	styles := make([]xlsxCellStyle, len(st.Xfs))
+	for i, xf := range st.Xfs {
+		styles[i].date = isDateFormat(xf.NumFmtID, codes[xf.NumFmtID])
+		if xf.FontID >= 0 && xf.FontID < len(st.Fonts) {
+			b := st.Fonts[xf.FontID].B
+			styles[i].bold = b != nil && b.Val != "0" && b.Val != "false"
+		}
+	}
+	return styles
+}
+
+// dateFormatNoise matches the quoted texts, escaped runes, colors and
+// locales of a number format, which do not make it a date format. The
+// elapsed times [h], [mm] and [ss] are kept.
+var dateFormatNoise = regexp.MustCompile(`(?i)"[^"]*"|\\.|\[[^\]hms][^\]]*\]`)
+
+// isDateFormat returns true if the number format with the id and the
+// code of custom formats shows dates or times.
+func isDateFormat(id int, code string) bool {
+	switch {
+	case id >= 14 && id <= 22, id >= 27 && id <= 36, id >= 45 && id <= 47, id >= 50 && id <= 58:
+		return true
+	case id < 164:
+		return false
+	}
+	code = strings.ToLower(dateFormatNoise.ReplaceAllString(code, ""))
+	return strings.ContainsAny(code, "ydhs")
+}
+
+// xlsxHyperlink matches the URL of a HYPERLINK formula.
+var xlsxHyperlink = regexp.MustCompile(`^HYPERLINK\("((?:[^"]|"")*)"`)
+
+// usedRange returns the used range of the sheet.
+func (sx xlsxSheetXML) usedRange(strs []string, styles []xlsxCellStyle, links map[string]string,
+	epoch time.Time) *sheet {
+	type cell struct {
+		text string
+		kind xlsxCellKind
+		bold bool
+		link string
+	}
+	cells := make(map[pos]cell)
+	minRow, minCol, maxRow, maxCol := math.MaxInt32, math.MaxInt32, -1, -1
+	rowIndex := -1
+	for _, r := range sx.Rows {
+		rowIndex++
+		if r.R > 0 {
+			rowIndex = r.R - 1
+		}
+		colIndex := -1
+		for _, c := range r.Cells {
+			colIndex++
+			if i, j, ok := parseRef(c.R); ok {
+				rowIndex, colIndex = i, j
+			}
+			var st xlsxCellStyle
+			if c.S >= 0 && c.S < len(styles) {
+				st = styles[c.S]
+			}
+			text, kind := xlsxValue(c.T, c.V, c.IS, strs, st.date, epoch)
+			link := links[c.R]
+			if m := xlsxHyperlink.FindStringSubmatch(c.F); m != nil {
+				link = strings.Replace(m[1], `""`, `"`, -1)
+			}
+			if kind == xlsxEmpty {
+				continue
+			}
+			cells[pos{rowIndex, colIndex}] = cell{text, kind, st.bold, link}
+			minRow, maxRow = minInt(minRow, rowIndex), maxInt(maxRow, rowIndex)
+			minCol, maxCol = minInt(minCol, colIndex), maxInt(maxCol, colIndex)
+		}
+	}
+	s := &sheet{links: make(map[pos]string)}
+	if maxRow < 0 {
+		return s
+	}
+	s.row0, s.col0 = minRow, minCol
+	for i := minRow; i <= maxRow; i++ {
+		n := maxCol - minCol + 1
+		texts, kinds, bold := make([]string, n), make([]xlsxCellKind, n), make([]bool, n)
+		for j := range texts {
+			c := cells[pos{i, j + minCol}]
+			texts[j], kinds[j], bold[j] = c.text, c.kind, c.bold
+			if c.link != "" {
+				s.links[pos{i - minRow, j}] = c.link
+			}
+		}
+		s.rows = append(s.rows, texts)
+		s.kinds = append(s.kinds, kinds)
+		s.bold = append(s.bold, bold)
+	}
+	for _, m := range sx.Merges {
+		refs := strings.SplitN(m.Ref, ":", 2)
+		if len(refs) != 2 {
+			continue
+		}
+		i0, j0, ok0 := parseRef(refs[0])
+		i1, j1, ok1 := parseRef(refs[1])
+		if !ok0 || !ok1 || i0 < minRow || j0 < minCol || i1 > maxRow || j1 > maxCol {
+			continue
+		}
+		s.spans = append(s.spans, row.Span{Row: i0 - minRow, Col: j0 - minCol,
+			Rows: i1 - i0 + 1, Cols: j1 - j0 + 1})
+	}
+	return s
+}
+
+// xlsxValue returns the text of a cell with the type t, the value v and
+// the inline string is.
+func xlsxValue(t, v string, is xlsxTextXML, strs []string, date bool,
+	epoch time.Time) (string, xlsxCellKind) {
+	var text string
+	kind := xlsxText
+	switch t {
+	case "s":
+		if i, err := strconv.Atoi(v); err == nil && i >= 0 && i < len(strs) {
+			text = strs[i]
+		}
+	case "inlineStr":
+		text = is.String()
+	case "str", "e":
+		text = v
+	case "b":
+		text, kind = "false", xlsxTyped
+		if v == "1" {
+			text = "true"
+		}
+		if v == "" {
+			text = ""
+		}
+	case "d":
+		text, kind = v, xlsxTyped
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+			if d, err := time.Parse(layout, v); err == nil {
+				text = formatSerialTime(d, d.Hour() == 0 && d.Minute() == 0 && d.Second() == 0)
+				break
+			}
+		}
+	default:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return "", xlsxEmpty
+		}
+		kind = xlsxTyped
+		text = strconv.FormatFloat(f, 'f', -1, 64)
+		if date {
+			d := epoch.Add(time.Duration(math.Round(f*86400)) * time.Second)
+			text = formatSerialTime(d, f == math.Trunc(f))
+		}
+	}
+	if text == "" {
+		return "", xlsxEmpty
+	}
+	return text, kind
+}
+
+// formatSerialTime formats d as date, or as date and time if dateOnly
+// is false.
+func formatSerialTime(d time.Time, dateOnly bool) string {
+	if dateOnly {
+		return d.Format("2006-01-02")
+	}
+	return d.Format("2006-01-02 15:04:05")
+}
+
+// parseRef returns the row and column index of a cell reference like B3.
+func parseRef(ref string) (int, int, bool) {
+	ref = strings.Replace(ref, "$", "", -1)
+	k := strings.IndexAny(ref, "0123456789")
+	if k <= 0 {
+		return 0, 0, false
+	}
+	var j int
+	for _, r := range strings.ToUpper(ref[:k]) {
+		if r < 'A' || r > 'Z' {
+			return 0, 0, false
+		}
+		j = j*26 + int(r-'A') + 1
+	}
+	i, err := strconv.Atoi(ref[k:])
+	if err != nil || i < 1 {
+		return 0, 0, false
+	}
+	return i - 1, j - 1, true
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,151 @@
+package table
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestReadXLSX_roundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := newSheetTable(XLSX{}).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	ta, err := ReadXLSX(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := [][]string{
+		{"Name", "When", "Qty", "Price"},
+		{"a&b", "2024-01-02", "3", "1.5"},
+		{"c", "2024-02-03 12:00:00", "4", "2.25"},
+		{"Total", "", "7", "1.88"}}
+	if !reflect.DeepEqual(ta.rows, exp) {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, ta.rows)
+	}
+	if ta.HeaderRows != 1 || ta.links[pos{1, 0}] != "http://x" {
+		t.Errorf("wrong header rows %d or links %v", ta.HeaderRows, ta.links)
+	}
+}
+
+// excelBook returns a workbook with shared strings, a custom date format,
+// merged cells and a hyperlink, like spreadsheet applications write it.
+func excelBook(t *testing.T) *bytes.Buffer {
+	ns := `xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"`
+	rels := `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`
+	files := []zipFile{
+		{"_rels/.rels", rels + `<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="/xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", `<workbook ` + ns + `><workbookPr date1904="1"/><sheets>` +
+			`<sheet name="Empty" sheetId="1" r:id="rId1"/><sheet name="Data" sheetId="2" r:id="rId2"/></sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", rels +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet2.xml"/>` +
+			`<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.xml"/>` +
+			`<Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`},
+		{"xl/sharedStrings.xml", `<sst ` + ns + `><si><t>Item</t></si><si><t>Day</t></si>` +
+			`<si><r><t>Rich </t></r><r><rPr><b/></rPr><t>text</t></r></si><si><t>Group</t></si></sst>`},
+		{"xl/styles.xml", `<styleSheet ` + ns + `><numFmts count="1"><numFmt numFmtId="170" formatCode="d/m/yyyy;@"/></numFmts>` +
+			`<fonts count="1"><font/></fonts><cellXfs count="2"><xf numFmtId="0" fontId="0"/><xf numFmtId="170" fontId="0"/></cellXfs></styleSheet>`},
+		{"xl/worksheets/sheet1.xml", `<worksheet ` + ns + `><sheetData/></worksheet>`},
+		{"xl/worksheets/sheet2.xml", `<worksheet ` + ns + `><sheetData>` +
+			`<row r="2"><c r="B2" t="s"><v>0</v></c><c r="C2" t="s"><v>1</v></c><c r="D2" t="s"><v>3</v></c></row>` +
+			`<row r="3"><c r="B3" t="s"><v>2</v></c><c r="C3" s="1"><v>0.5</v></c><c r="D3"><v>0.30000000000000004</v></c><c r="E3"/></row>` +
+			`<row r="4"><c r="B4" t="b"><v>1</v></c><c r="C4" s="1"><v>1</v></c><c r="D4" t="e"><v>#DIV/0!</v></c></row>` +
+			`</sheetData><mergeCells count="1"><mergeCell ref="B3:B4"/></mergeCells>` +
+			`<hyperlinks><hyperlink ref="D2" r:id="rId1"/></hyperlinks></worksheet>`},
+		{"xl/worksheets/_rels/sheet2.xml.rels", rels + `<Relationship Id="rId1" ` +
+			`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="http://g" TargetMode="External"/></Relationships>`},
+	}
+	var buf bytes.Buffer
+	if err := writeZip(&buf, files); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestReadXLSX_sheet(t *testing.T) {
+	for _, opt := range []Option{WithSheet("Data"), WithSheetIndex(1)} {
+		ta, err := ReadXLSX(excelBook(t), opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp := [][]string{
+			{"Item", "Day", "Group"},
+			{"Rich text", "1904-01-01 12:00:00", "0.30000000000000004"},
+			{"true", "1904-01-02", "#DIV/0!"}}
+		if !reflect.DeepEqual(ta.rows, exp) {
+			t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, ta.rows)
+		}
+		if ta.HeaderRows != 1 {
+			t.Errorf("the first row should be detected as header, header rows: %d", ta.HeaderRows)
+		}
+		if len(ta.spans) != 1 || ta.spans[0].Row != 1 || ta.spans[0].Rows != 2 {
+			t.Errorf("wrong spans: %v", ta.spans)
+		}
+		if ta.links[pos{0, 2}] != "http://g" {
+			t.Errorf("wrong links: %v", ta.links)
+		}
+	}
+}
+
+func TestReadXLSX_options(t *testing.T) {
+	ta, err := ReadXLSX(excelBook(t), WithSheet("Data"), WithHeader(0), WithTitle("T", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ta.HeaderRows != 0 || ta.Title != "T" {
+		t.Errorf("the options should be applied: %d %q", ta.HeaderRows, ta.Title)
+	}
+	ta, err = ReadXLSX(excelBook(t))
+	if err != nil || len(ta.rows) != 0 {
+		t.Fatalf("the first sheet should be empty: %v %v", ta.rows, err)
+	}
+	if m, err := ta.Model(); err != nil || len(m.Body) != 0 {
+		t.Errorf("the model of the empty sheet should be empty: %v %v", m, err)
+	}
+	if s := ta.String(); s != "" {
+		t.Errorf("the empty sheet should draw nothing: %q", s)
+	}
+	ta, err = ReadXLSX(excelBook(t), WithSheet("Data"), WithHeader(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ta.spans) != 0 {
+		t.Errorf("the merged cells crossing the header should be cut: %v", ta.spans)
+	}
+	for _, opts := range [][]Option{{WithSheet("x")}, {WithSheetIndex(2)}, {WithComma(';')},
+		{WithSheet("Data"), WithHeader(4)}, {WithSheetIndex(-1)}} {
+		if _, err := ReadXLSX(excelBook(t), opts...); err == nil {
+			t.Errorf("options %d should fail", len(opts))
+		}
+	}
+	if _, err := ReadXLSX(bytes.NewReader([]byte("no zip"))); err == nil {
+		t.Error("reading no zip archive should fail")
+	}
+	if _, err := NewWith([][]string{{"a"}}, WithSheet("x")); err != errSheet {
+		t.Errorf("NewWith should fail with errSheet, is %v", err)
+	}
+}
+
+func TestIsDateFormat(t *testing.T) {
+	for code, exp := range map[string]bool{"yyyy-mm-dd": true, "[h]:mm": true, `0.00 "days"`: false,
+		"#,##0": false, "[Red]0.0": false, "General": false} {
+		if b := isDateFormat(164, code); b != exp {
+			t.Errorf("%q should be %v", code, exp)
+		}
+	}
+	if !isDateFormat(14, "") || isDateFormat(2, "") {
+		t.Error("wrong built-in date formats")
+	}
+}
+
+func TestParseRef(t *testing.T) {
+	for ref, exp := range map[string][3]int{"A1": {0, 0, 1}, "$B$3": {2, 1, 1}, "AA10": {9, 26, 1},
+		"A0": {0, 0, 0}, "1": {0, 0, 0}, "A": {0, 0, 0}} {
+		i, j, ok := parseRef(ref)
+		if ok != (exp[2] == 1) || ok && (i != exp[0] || j != exp[1]) {
+			t.Errorf("%s should be %v, is %d %d %v", ref, exp, i, j, ok)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package table
+
+import (
+	"io"
+
+	"github.com/thibran/table/row"
+)
+
+// render writes the model of the table with its Renderer.
+func (t *Table) render(w io.Writer) (int64, error) {
+	m, err := t.Model()
+	if err != nil {
+		return 0, err
+	}
+	cw := &countWriter{w: w}
+	err = t.Renderer.Render(cw, m)
+	return cw.n, err
+}
+
+// countWriter counts the bytes written to w.
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// ASCII renders the model as text table, like a Table without Renderer.
+// Nil styles use the styles of New.
+type ASCII struct {
+	HeadStyle  *Style
+	BodyStyle  *Style
+	Hyperlinks bool // if true, links are written as OSC 8 escapes
+}
+
+// Render the model m.
+func (a ASCII) Render(w io.Writer, m *Model) error {
+	t := modelTable(m, nil)
+	if a.HeadStyle != nil {
+		t.HeadStyle = a.HeadStyle
+	}
+	if a.BodyStyle != nil {
+		t.BodyStyle = a.BodyStyle
+	}
+	t.Hyperlinks = a.Hyperlinks
+	_, err := t.WriteTo(w)
+	return err
+}
+
+// modelTable returns a table with the default settings drawing the model.
+// If text is not nil, it returns the text written for a cell.
+func modelTable(m *Model, text func(c Cell) string) *Table {
+	t := newTable()
+	t.Title, t.Caption = m.Title, m.Caption
+	rows := m.rows()
+	t.rows = make([][]string, len(rows))
+	t.paints = make([][]row.Paint, len(rows))
+	for i, cells := range rows {
+		t.rows[i] = make([]string, len(cells))
+		t.paints[i] = make([]row.Paint, len(cells))
+		for j, c := range cells {
+			t.rows[i][j] = c.Text
+			if text != nil {
+				t.rows[i][j] = text(c)
+			}
+			t.paints[i][j] = c.Paint
+			if c.Link != "" {
+				t.Link(i, j, c.Link)
+			}
+			if !c.Merged && (c.Rows > 1 || c.Cols > 1) {
+				t.spans = append(t.spans, row.Span{Row: i, Col: j, Rows: c.Rows, Cols: c.Cols})
+			}
+		}
+	}
+	t.r = newReader(t.rows)
+	t.HeaderRows = len(m.Header)
+	t.columnCap = make(row.ColumnCap, len(m.Columns))
+	t.Align = make([]row.Align, len(m.Columns))
+	t.fixed = make([]bool, len(m.Columns))
+	for j, c := range m.Columns {
+		t.columnCap[j] = c.Width
+		t.Align[j] = c.Align
+		t.fixed[j] = true
+	}
+	return t
+}
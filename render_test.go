@@ -0,0 +1,47 @@
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+// countRenderer writes the number of body rows.
+type countRenderer struct{}
+
+func (countRenderer) Render(w io.Writer, m *Model) error {
+	_, err := fmt.Fprintf(w, "%d rows", len(m.Body))
+	return err
+}
+
+func TestTable_renderer(t *testing.T) {
+	ta, _ := New(true, [][]string{{"a"}, {"b"}, {"c"}}...)
+	ta.Renderer = countRenderer{}
+	var b bytes.Buffer
+	n, err := ta.WriteTo(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != "2 rows" || n != 6 {
+		t.Errorf("should be %q with %d bytes but is %q with %d", "2 rows", 6, b.String(), n)
+	}
+}
+
+func TestASCII(t *testing.T) {
+	ta := newModelTable()
+	exp := newModelTable().String()
+	ta.Renderer = ASCII{}
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestASCII_style(t *testing.T) {
+	ta, _ := New(true, [][]string{{"a"}, {"b"}}...)
+	ta.Renderer = ASCII{HeadStyle: StyleEmpty()}
+	exp := "a \nb "
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
@@ -0,0 +1,44 @@
+package row
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Align is the horizontal alignment of the text of a cell.
+type Align int
+
+// Alignments, AlignLeft is the default.
+const (
+	AlignLeft Align = iota
+	AlignRight
+	AlignCenter
+)
+
+// Pad s with whitespace to width runes, so that it is aligned as a.
+// Longer strings are returned unchanged.
+func (a Align) Pad(s string, width int) string {
+	n := width - utf8.RuneCountInString(s)
+	if n <= 0 {
+		return s
+	}
+	switch a {
+	case AlignRight:
+		return strings.Repeat(" ", n) + s
+	case AlignCenter:
+		return strings.Repeat(" ", n/2) + s + strings.Repeat(" ", n-n/2)
+	}
+	return s + strings.Repeat(" ", n)
+}
+
+func (a Align) String() string {
+	switch a {
+	case AlignLeft:
+		return "left"
+	case AlignRight:
+		return "right"
+	case AlignCenter:
+		return "center"
+	}
+	return "invalid"
+}
@@ -0,0 +1,32 @@
+package row
+
+import "testing"
+
+func TestAlign_pad(t *testing.T) {
+	tests := []struct {
+		a   Align
+		exp string
+	}{
+		{AlignLeft, "ab   "},
+		{AlignRight, "   ab"},
+		{AlignCenter, " ab  "},
+	}
+	for _, tt := range tests {
+		if s := tt.a.Pad("ab", 5); s != tt.exp {
+			t.Errorf("%v should be %q but is %q", tt.a, tt.exp, s)
+		}
+	}
+	if s := AlignRight.Pad("abc", 2); s != "abc" {
+		t.Errorf("should be %q but is %q", "abc", s)
+	}
+}
+
+func TestNewAligned(t *testing.T) {
+	c := ColumnCap{5, 5}
+	p := []Padding{{Right: 1}}
+	r, _ := NewAligned(c, []string{"ab", "cd"}, []Padding{p[0], p[0]}, nil,
+		[]Align{AlignRight, AlignCenter})
+	if r[0] != "  ab " || r[1] != " cd  " {
+		t.Errorf("should be [%q %q] but is %q", "  ab ", " cd  ", r)
+	}
+}
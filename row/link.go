@@ -0,0 +1,17 @@
+package row
+
+import "strings"
+
+// Hyperlink wraps the text of the padded cell s in an OSC 8 escape
+// sequence, which links it to url in capable terminals. The padding
+// stays outside of the link.
+func Hyperlink(s, url string) string {
+	if url == "" {
+		return s
+	}
+	text := strings.TrimLeft(s, " ")
+	left := s[:len(s)-len(text)]
+	text = strings.TrimRight(text, " ")
+	right := s[len(left)+len(text):]
+	return left + "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\" + right
+}
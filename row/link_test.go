@@ -0,0 +1,17 @@
+package row
+
+import "testing"
+
+func TestHyperlink(t *testing.T) {
+	s := Hyperlink(" PR 12  ", "https://example.com/12")
+	exp := " \x1b]8;;https://example.com/12\x1b\\PR 12\x1b]8;;\x1b\\  "
+	if s != exp {
+		t.Errorf("should be %q but is %q", exp, s)
+	}
+}
+
+func TestHyperlink_noURL(t *testing.T) {
+	if s := Hyperlink("a ", ""); s != "a " {
+		t.Errorf("should be %q but is %q", "a ", s)
+	}
+}
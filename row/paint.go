@@ -0,0 +1,57 @@
+package row
+
+// Paint holds the SGR parameters of a terminal escape sequence, e.g.
+// "1;31" for bold red text. An empty Paint draws plain text.
+type Paint string
+
+// Common text attributes and colors.
+const (
+	Bold      Paint = "1"
+	Dim       Paint = "2"
+	Italic    Paint = "3"
+	Underline Paint = "4"
+	Inverse   Paint = "7"
+
+	Red     Paint = "31"
+	Green   Paint = "32"
+	Yellow  Paint = "33"
+	Blue    Paint = "34"
+	Magenta Paint = "35"
+	Cyan    Paint = "36"
+
+	BgRed    Paint = "41"
+	BgGreen  Paint = "42"
+	BgYellow Paint = "43"
+	BgBlue   Paint = "44"
+	BgGray   Paint = "100"
+)
+
+// Reset ends all attributes.
+const Reset = "\x1b[0m"
+
+// Add combines both paints, the attributes of o win.
+func (p Paint) Add(o Paint) Paint {
+	switch {
+	case p == "":
+		return o
+	case o == "":
+		return p
+	}
+	return p + ";" + o
+}
+
+// Start returns the escape sequence which switches to p.
+func (p Paint) Start() string {
+	if p == "" {
+		return ""
+	}
+	return "\x1b[" + string(p) + "m"
+}
+
+// Wrap s in the escape sequences to start and end p.
+func (p Paint) Wrap(s string) string {
+	if p == "" {
+		return s
+	}
+	return p.Start() + s + Reset
+}
@@ -0,0 +1,24 @@
+package row
+
+import "testing"
+
+func TestPaint_add(t *testing.T) {
+	if p := Bold.Add(Red); p != "1;31" {
+		t.Errorf("should be %q but is %q", "1;31", p)
+	}
+	if p := Paint("").Add(Red); p != Red {
+		t.Errorf("should be %q but is %q", Red, p)
+	}
+	if p := Red.Add(""); p != Red {
+		t.Errorf("should be %q but is %q", Red, p)
+	}
+}
+
+func TestPaint_wrap(t *testing.T) {
+	if s := Red.Wrap("a"); s != "\x1b[31ma\x1b[0m" {
+		t.Errorf("should be %q but is %q", "\x1b[31ma\x1b[0m", s)
+	}
+	if s := Paint("").Wrap("a"); s != "a" {
+		t.Errorf("should be %q but is %q", "a", s)
+	}
+}
@@ -3,6 +3,7 @@ package row
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"unicode/utf8"
 )
@@ -16,55 +17,149 @@ type ColumnCap []int
 var errItemCountNotEqual = errors.New(`Number of items in the head and body []string must be
 equal if there are more than 0 of either of each.`)
 
+// Padding is the whitespace left and right of the text of a cell.
+type Padding struct {
+	Left, Right int
+}
+
 // New Row object. Line break will be striped from the strings
 // and n whitespace added to the end of the cell.
 func New(c ColumnCap, row []string, n uint8) Row {
+	p := make([]Padding, len(c))
+	for i := range p {
+		p[i].Right = int(n)
+	}
+	r, _ := NewPadded(c, row, p, nil)
+	return r
+}
+
+// NewPadded Row object. Line break will be striped from the strings
+// and the padding p[i] added around the text of cell i. Too long cells
+// are cut as described by tr[i], the last entry of tr is used for the
+// remaining columns and DefaultTruncate if tr is empty. The returned
+// slice reports which cells were cut.
+func NewPadded(c ColumnCap, row []string, p []Padding, tr []Truncate) (Row, []bool) {
+	return NewAligned(c, row, p, tr, nil)
+}
+
+// NewAligned Row object, see NewPadded. The text of cell i is aligned
+// within its column as described by a[i], the last entry of a is used
+// for the remaining columns and AlignLeft if a is empty.
+func NewAligned(c ColumnCap, row []string, p []Padding, tr []Truncate,
+	a []Align) (Row, []bool) {
 	// trim too long rows
 	if len(row) > len(c) {
 		row = row[:len(c)]
 	}
+	cut := make([]bool, len(row))
 	for i, cell := range row {
+		t := DefaultTruncate
+		switch {
+		case i < len(tr):
+			t = tr[i]
+		case len(tr) > 0:
+			t = tr[len(tr)-1]
+		}
 		cell = purgeRunes(cell)
-		cell = trimCell(cell, c[i]-int(n))
-		row[i] = fmt.Sprintf("%s%s", cell, strings.Repeat(" ", int(n)))
+		width := c[i] - p[i].Left - p[i].Right
+		cell, cut[i] = t.Cut(cell, width)
+		switch {
+		case i < len(a):
+			cell = a[i].Pad(cell, width)
+		case len(a) > 0:
+			cell = a[len(a)-1].Pad(cell, width)
+		}
+		row[i] = fmt.Sprintf("%s%s%s", strings.Repeat(" ", p[i].Left), cell,
+			strings.Repeat(" ", p[i].Right))
 	}
-	return Row(row)
+	return Row(row), cut
 }
 
 // trimCell if it is longer than max runes.
 func trimCell(cell string, max int) string {
-	if max < 0 {
-		max = 0
-	}
-	count := utf8.RuneCountInString(cell)
-	switch {
-	case count <= max: // do nothing
-		return cell
-	case max <= 3: // trim without adding dots
-		return cell[:max]
-	default: // trim + dots
-		return fmt.Sprintf("%s...", cell[:max-3])
-	}
+	cell, _ = DefaultTruncate.Cut(cell, max)
+	return cell
 }
 
 // NewColumnCap calculate ColumnCap for the rows with n whitespace added.
-func NewColumnCap(rows [][]string, n uint8) ColumnCap {
-	c := make(ColumnCap, len(rows[0]))
-	for _, row := range rows {
-		for i, cell := range row {
-			cell = purgeRunes(cell)
-			count := utf8.RuneCountInString(cell) + int(n)
-			if count > c[i] {
-				c[i] = count
+// The text of merged cells is not counted for a single column. If it does
+// not fit into the columns of its span, the last column is widened. The
+// columns are assumed to be drawn without lines or gaps between them.
+func NewColumnCap(rows [][]string, n uint8, spans ...Span) ColumnCap {
+	p := make([]Padding, columnCount(rows))
+	for i := range p {
+		p[i].Right = int(n)
+	}
+	return NewPaddedColumnCap(rows, p, 0, spans...)
+}
+
+// NewPaddedColumnCap calculate ColumnCap for the rows with the padding
+// p[i] added to column i, see NewColumnCap. sep is the width between two
+// columns, e.g. of a vertical line, which a span covers as well.
+func NewPaddedColumnCap(rows [][]string, p []Padding, sep int, spans ...Span) ColumnCap {
+	columns := columnCount(rows)
+	c := make(ColumnCap, columns)
+	s := Spans(spans)
+	for i, row := range rows {
+		for j, cell := range row {
+			if _, ok := s.At(i, j); ok {
+				continue
 			}
-			if c[i] == 0 {
-				c[i] = 1
+			cell = purgeRunes(cell)
+			count := utf8.RuneCountInString(cell) + p[j].Left + p[j].Right
+			if count > c[j] {
+				c[j] = count
 			}
 		}
 	}
+	for j := range c {
+		if c[j] == 0 {
+			c[j] = 1
+		}
+	}
+	// widen narrow spans first, wide spans might fit afterwards
+	s = append(Spans(nil), s...)
+	sort.SliceStable(s, func(a, b int) bool { return s[a].Cols < s[b].Cols })
+	for _, sp := range s {
+		if sp.Row >= len(rows) || sp.Col >= len(rows[sp.Row]) {
+			continue
+		}
+		last := sp.Col + sp.Cols - 1
+		if last >= columns {
+			last = columns - 1
+		}
+		width := (last - sp.Col) * sep
+		for _, count := range c[sp.Col : last+1] {
+			width += count
+		}
+		cell := purgeRunes(rows[sp.Row][sp.Col])
+		count := utf8.RuneCountInString(cell) + p[sp.Col].Left + p[last].Right
+		if count > width {
+			c[last] += count - width
+		}
+	}
 	return c
 }
 
+func columnCount(rows [][]string) int {
+	var columns int
+	for _, row := range rows {
+		if len(row) > columns {
+			columns = len(row)
+		}
+	}
+	return columns
+}
+
+// Padded returns the ColumnCap with the padding p[i] added to column i.
+func (c ColumnCap) Padded(p []Padding) ColumnCap {
+	padded := make(ColumnCap, len(c))
+	for i, count := range c {
+		padded[i] = count + p[i].Left + p[i].Right
+	}
+	return padded
+}
+
 // ConvRunesPerColumn calculates ColumnCap (incorporate added whitespace n).
 func ConvRunesPerColumn(runesPerColumn []int, n uint8) ColumnCap {
 	c := make(ColumnCap, len(runesPerColumn))
@@ -94,3 +189,33 @@ func TrimTextToMaxLength(s string, n int) string {
 	}
 	return fmt.Sprintf("%s%s", s, strings.Repeat(" ", n-size))
 }
+
+// Wrap splits s into lines of at most width runes. Lines are broken at
+// spaces if possible, line breaks in s are kept.
+func Wrap(s string, width int) []string {
+	var lines []string
+	for _, par := range strings.Split(strings.Replace(s, "\r", "", -1), "\n") {
+		if width <= 0 {
+			lines = append(lines, par)
+			continue
+		}
+		var line []rune
+		for _, word := range strings.Fields(par) {
+			w := []rune(word)
+			if len(line) > 0 && len(line)+1+len(w) > width {
+				lines = append(lines, string(line))
+				line = line[:0]
+			}
+			if len(line) > 0 {
+				line = append(line, ' ')
+			}
+			line = append(line, w...)
+			for len(line) > width {
+				lines = append(lines, string(line[:width]))
+				line = append(line[:0], line[width:]...)
+			}
+		}
+		lines = append(lines, string(line))
+	}
+	return lines
+}
@@ -1,6 +1,9 @@
 package row
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestNew_default(t *testing.T) {
 	c := ColumnCap{3, 2}
@@ -163,3 +166,99 @@ func TestTrimCell_zero(t *testing.T) {
 		t.Errorf("should be %q but is %q", exp, s)
 	}
 }
+
+func TestNewColumnCap_ragged(t *testing.T) {
+	c := NewColumnCap([][]string{{"a"}, {"bb", "ccc"}}, 0)
+	if len(c) != 2 || c[0] != 2 || c[1] != 3 {
+		t.Errorf("should be [2 3] but is %v", c)
+	}
+}
+
+func TestNewColumnCap_span(t *testing.T) {
+	rows := [][]string{
+		{"Latency", ""},
+		{"p50", "p99"},
+	}
+	c := NewColumnCap(rows, 1, Span{Row: 0, Col: 0, Rows: 1, Cols: 2})
+	if c[0] != 4 || c[1] != 4 {
+		t.Errorf("should be [4 4] but is %v", c)
+	}
+}
+
+func TestNewColumnCap_spanWiden(t *testing.T) {
+	rows := [][]string{
+		{"Benchmark results", ""},
+		{"p50", "p99"},
+	}
+	c := NewColumnCap(rows, 1, Span{Row: 0, Col: 0, Rows: 1, Cols: 2})
+	if c[0] != 4 || c[1] != 14 {
+		t.Errorf("should be [4 14] but is %v", c)
+	}
+}
+
+func TestNewPaddedColumnCap_spanSep(t *testing.T) {
+	rows := [][]string{
+		{"Benchmark results", ""},
+		{"p50", "p99"},
+	}
+	p := []Padding{{Right: 1}, {Right: 1}}
+	c := NewPaddedColumnCap(rows, p, 1, Span{Row: 0, Col: 0, Rows: 1, Cols: 2})
+	if c[0] != 4 || c[1] != 13 {
+		t.Errorf("should be [4 13] but is %v", c)
+	}
+}
+
+func TestWrap(t *testing.T) {
+	lines := Wrap("a bb ccc\ndddddd", 4)
+	exp := []string{"a bb", "ccc", "dddd", "dd"}
+	if strings.Join(lines, "|") != strings.Join(exp, "|") {
+		t.Errorf("should be %q but is %q", exp, lines)
+	}
+}
+
+func TestWrap_noWidth(t *testing.T) {
+	lines := Wrap("a b", 0)
+	if len(lines) != 1 || lines[0] != "a b" {
+		t.Errorf("should be %q but is %q", "a b", lines)
+	}
+}
+
+func TestNewPadded(t *testing.T) {
+	c := ColumnCap{5, 4}
+	p := []Padding{{Left: 1, Right: 1}, {Left: 2}}
+	r, cut := NewPadded(c, []string{"ab", "cdef"}, p, nil)
+	if r[0] != " ab " {
+		t.Errorf("r[0] should be %q but is %q", " ab ", r[0])
+	}
+	if r[1] != "  cd" {
+		t.Errorf("r[1] should be %q but is %q", "  cd", r[1])
+	}
+	if cut[0] || !cut[1] {
+		t.Errorf("only cell 1 should be cut: %v", cut)
+	}
+}
+
+func TestNewPadded_truncate(t *testing.T) {
+	c := ColumnCap{4, 4}
+	p := []Padding{{}, {}}
+	tr := []Truncate{{Mode: TruncateStart, Ellipsis: "…"}}
+	r, _ := NewPadded(c, []string{"abcdef", "123456"}, p, tr)
+	if r[0] != "…def" || r[1] != "…456" {
+		t.Errorf("should be [%q %q] but is %q", "…def", "…456", r)
+	}
+}
+
+func TestNewPaddedColumnCap(t *testing.T) {
+	p := []Padding{{Left: 1, Right: 1}, {Left: 2}}
+	c := NewPaddedColumnCap([][]string{{"a", "bb"}, {"ccc", ""}}, p, 0)
+	if c[0] != 5 || c[1] != 4 {
+		t.Errorf("should be [5 4] but is %v", c)
+	}
+}
+
+func TestColumnCap_padded(t *testing.T) {
+	c := ColumnCap{1, 2}.Padded([]Padding{{Left: 1}, {Right: 2}})
+	if c[0] != 2 || c[1] != 4 {
+		t.Errorf("should be [2 4] but is %v", c)
+	}
+}
@@ -0,0 +1,74 @@
+package row
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Rule formats the cells of a column which match. A negative Column
+// applies the rule to every column.
+type Rule struct {
+	Column int
+	Match  func(cell string) bool
+	Paint  Paint  // terminal paint of the cell
+	Class  string // CSS class of the cell in HTML output
+	Text   string // if not empty, replaces the text of the cell
+}
+
+// Rules is a list of Rule objects, all matching rules are applied in order.
+type Rules []Rule
+
+// Format of a single cell, the result of the applied rules.
+type Format struct {
+	Paint Paint
+	Class string
+}
+
+// Apply the rules to the cells. Texts are replaced in place and the
+// format of every cell is returned.
+func (rs Rules) Apply(cells []string) []Format {
+	f := make([]Format, len(cells))
+	for _, r := range rs {
+		for j, cell := range cells {
+			if (r.Column >= 0 && r.Column != j) || !r.Match(cell) {
+				continue
+			}
+			f[j].Paint = f[j].Paint.Add(r.Paint)
+			if r.Class != "" {
+				f[j].Class = strings.TrimSpace(f[j].Class + " " + r.Class)
+			}
+			if r.Text != "" {
+				cells[j] = r.Text
+			}
+		}
+	}
+	return f
+}
+
+// Greater matches cells with a number greater than v.
+func Greater(v float64) func(cell string) bool {
+	return func(cell string) bool {
+		f, err := strconv.ParseFloat(strings.TrimSpace(cell), 64)
+		return err == nil && f > v
+	}
+}
+
+// Less matches cells with a number less than v.
+func Less(v float64) func(cell string) bool {
+	return func(cell string) bool {
+		f, err := strconv.ParseFloat(strings.TrimSpace(cell), 64)
+		return err == nil && f < v
+	}
+}
+
+// Equal matches cells with the text s.
+func Equal(s string) func(cell string) bool {
+	return func(cell string) bool {
+		return cell == s
+	}
+}
+
+// Empty matches cells without text.
+func Empty(cell string) bool {
+	return strings.TrimSpace(cell) == ""
+}
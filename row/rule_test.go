@@ -0,0 +1,34 @@
+package row
+
+import "testing"
+
+func TestRules_apply(t *testing.T) {
+	rs := Rules{
+		{Column: 1, Match: Greater(90), Paint: Red.Add(Bold), Class: "high"},
+		{Column: -1, Match: Equal("FAIL"), Paint: Inverse, Class: "fail"},
+		{Column: 2, Match: Empty, Paint: Dim, Text: "-"},
+	}
+	cells := []string{"FAIL", "91.5", ""}
+	f := rs.Apply(cells)
+	exp := []Format{
+		{Paint: Inverse, Class: "fail"},
+		{Paint: "31;1", Class: "high"},
+		{Paint: Dim},
+	}
+	for j := range exp {
+		if f[j] != exp[j] {
+			t.Errorf("[%d] should be %v but is %v", j, exp[j], f[j])
+		}
+	}
+	if cells[2] != "-" {
+		t.Errorf("should be %q but is %q", "-", cells[2])
+	}
+}
+
+func TestRules_noMatch(t *testing.T) {
+	rs := Rules{{Column: 0, Match: Less(10), Paint: Red}}
+	f := rs.Apply([]string{"abc", "1"})
+	if f[0].Paint != "" || f[1].Paint != "" {
+		t.Errorf("should not be painted: %v", f)
+	}
+}
@@ -0,0 +1,72 @@
+package row
+
+// Span merges a cell with its neighbours to the right and below.
+// Row and Col are the zero based position of the top-left cell,
+// Rows and Cols the number of rows and columns the span covers.
+type Span struct {
+	Row, Col   int
+	Rows, Cols int
+}
+
+// Spans is a list of non-overlapping Span objects.
+type Spans []Span
+
+// Covers returns true if the cell at row i, column j is part of the span.
+func (s Span) Covers(i, j int) bool {
+	return i >= s.Row && i < s.Row+s.Rows && j >= s.Col && j < s.Col+s.Cols
+}
+
+// Overlaps returns true if s and o share at least one cell.
+func (s Span) Overlaps(o Span) bool {
+	return s.Row < o.Row+o.Rows && o.Row < s.Row+s.Rows &&
+		s.Col < o.Col+o.Cols && o.Col < s.Col+s.Cols
+}
+
+// At returns the span covering the cell at row i, column j.
+// If the cell is not merged, false is returned.
+func (s Spans) At(i, j int) (Span, bool) {
+	for _, sp := range s {
+		if sp.Covers(i, j) {
+			return sp, true
+		}
+	}
+	return Span{}, false
+}
+
+// Same returns true if both cells are part of the same span.
+func (s Spans) Same(i1, j1, i2, j2 int) bool {
+	sp, ok := s.At(i1, j1)
+	return ok && sp.Covers(i2, j2)
+}
+
+// Overlaps returns true if sp shares a cell with any of the spans.
+func (s Spans) Overlaps(sp Span) bool {
+	for _, o := range s {
+		if o.Overlaps(sp) {
+			return true
+		}
+	}
+	return false
+}
+
+// Project returns the spans of a table which only contains the columns
+// cols, ordered ascending. A span covering several of the columns is
+// merged over all of them. Spans without any of the columns are dropped.
+func (s Spans) Project(cols []int) Spans {
+	var p Spans
+	for _, sp := range s {
+		first, n := -1, 0
+		for k, j := range cols {
+			if j >= sp.Col && j < sp.Col+sp.Cols {
+				if first < 0 {
+					first = k
+				}
+				n++
+			}
+		}
+		if n > 0 {
+			p = append(p, Span{Row: sp.Row, Col: first, Rows: sp.Rows, Cols: n})
+		}
+	}
+	return p
+}
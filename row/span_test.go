@@ -0,0 +1,61 @@
+package row
+
+import "testing"
+
+func TestSpans_at(t *testing.T) {
+	s := Spans{{Row: 1, Col: 1, Rows: 2, Cols: 2}}
+	if _, ok := s.At(0, 1); ok {
+		t.Error("cell 0,1 should not be merged")
+	}
+	sp, ok := s.At(2, 2)
+	if !ok || sp.Row != 1 || sp.Col != 1 {
+		t.Errorf("cell 2,2 should be merged but is %v %v", sp, ok)
+	}
+}
+
+func TestSpans_same(t *testing.T) {
+	s := Spans{
+		{Row: 0, Col: 0, Rows: 1, Cols: 2},
+		{Row: 0, Col: 2, Rows: 1, Cols: 2},
+	}
+	if !s.Same(0, 0, 0, 1) {
+		t.Error("cells 0,0 and 0,1 should be the same span")
+	}
+	if s.Same(0, 1, 0, 2) {
+		t.Error("cells 0,1 and 0,2 should be different spans")
+	}
+	if s.Same(1, 0, 1, 1) {
+		t.Error("cells of row 1 should not be merged")
+	}
+}
+
+func TestSpans_overlaps(t *testing.T) {
+	s := Spans{{Row: 0, Col: 1, Rows: 2, Cols: 2}}
+	if !s.Overlaps(Span{Row: 1, Col: 0, Rows: 1, Cols: 2}) {
+		t.Error("should overlap")
+	}
+	if s.Overlaps(Span{Row: 2, Col: 0, Rows: 1, Cols: 3}) {
+		t.Error("should not overlap")
+	}
+}
+
+func TestSpans_project(t *testing.T) {
+	s := Spans{
+		{Row: 0, Col: 0, Rows: 1, Cols: 4},
+		{Row: 1, Col: 1, Rows: 1, Cols: 2},
+		{Row: 2, Col: 3, Rows: 2, Cols: 1},
+	}
+	p := s.Project([]int{0, 3})
+	exp := Spans{
+		{Row: 0, Col: 0, Rows: 1, Cols: 2},
+		{Row: 2, Col: 1, Rows: 2, Cols: 1},
+	}
+	if len(p) != len(exp) {
+		t.Fatalf("should be %v but is %v", exp, p)
+	}
+	for i := range exp {
+		if p[i] != exp[i] {
+			t.Errorf("should be %v but is %v", exp, p)
+		}
+	}
+}
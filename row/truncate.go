@@ -0,0 +1,49 @@
+package row
+
+import "unicode/utf8"
+
+// TruncateMode is the part of the text which is cut.
+type TruncateMode int
+
+// Truncate modes, TruncateMiddle keeps the start and the end of the
+// text, e.g. for file paths and hashes.
+const (
+	TruncateEnd TruncateMode = iota
+	TruncateStart
+	TruncateMiddle
+)
+
+// Truncate describes how too long cells are cut.
+type Truncate struct {
+	Mode     TruncateMode
+	Ellipsis string // marks the removed text, e.g. "…"
+}
+
+// DefaultTruncate cuts the end of the text and appends three dots.
+var DefaultTruncate = Truncate{Mode: TruncateEnd, Ellipsis: "..."}
+
+// Cut s to at most max runes. If there is no room for the text and the
+// ellipsis, the text is cut without ellipsis. The returned bool is true,
+// if s was cut.
+func (t Truncate) Cut(s string, max int) (string, bool) {
+	if max < 0 {
+		max = 0
+	}
+	r := []rune(s)
+	if len(r) <= max {
+		return s, false
+	}
+	e := t.Ellipsis
+	if utf8.RuneCountInString(e) >= max {
+		e = ""
+	}
+	keep := max - utf8.RuneCountInString(e)
+	switch t.Mode {
+	case TruncateStart:
+		return e + string(r[len(r)-keep:]), true
+	case TruncateMiddle:
+		head := (keep + 1) / 2
+		return string(r[:head]) + e + string(r[len(r)-keep+head:]), true
+	}
+	return string(r[:keep]) + e, true
+}
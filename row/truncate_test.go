@@ -0,0 +1,30 @@
+package row
+
+import "testing"
+
+func TestTruncate_cut(t *testing.T) {
+	tests := []struct {
+		t   Truncate
+		s   string
+		max int
+		exp string
+	}{
+		{DefaultTruncate, "abcdef", 5, "ab..."},
+		{DefaultTruncate, "abcdef", 3, "abc"},
+		{DefaultTruncate, "äöüß", 4, "äöüß"},
+		{DefaultTruncate, "äöüßxy", 4, "ä..."},
+		{Truncate{Mode: TruncateStart, Ellipsis: "…"}, "/usr/local/bin", 6, "…l/bin"},
+		{Truncate{Mode: TruncateMiddle, Ellipsis: "…"}, "0123456789", 6, "012…89"},
+		{Truncate{Mode: TruncateMiddle}, "0123456789", 4, "0189"},
+		{Truncate{Mode: TruncateEnd, Ellipsis: "…"}, "abc", 1, "a"},
+	}
+	for i, tt := range tests {
+		s, cut := tt.t.Cut(tt.s, tt.max)
+		if s != tt.exp {
+			t.Errorf("[%d] should be %q but is %q", i, tt.exp, s)
+		}
+		if cut != (tt.s != tt.exp) {
+			t.Errorf("[%d] cut should be %v", i, !cut)
+		}
+	}
+}
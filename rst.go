@@ -0,0 +1,185 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/thibran/table/row"
+)
+
+// RST renders the model as reStructuredText grid table, or as simple
+// table if Simple is set. A title wraps the table into a table directive,
+// the caption of the model is written as paragraph below the table.
+//
+// Simple tables can not merge cells of the body, the text of a span is
+// written into its first cell. Header cells spanning several columns are
+// underlined.
+type RST struct {
+	Simple bool // if true, a simple table is written instead of a grid table
+}
+
+// Render the model m.
+func (r RST) Render(w io.Writer, m *Model) error {
+	var b strings.Builder
+	indent := ""
+	if m.Title != "" {
+		fmt.Fprintf(&b, ".. table:: %s\n\n", rstEscape(m.Title))
+		indent = "   "
+	}
+	var table string
+	if r.Simple {
+		table = rstSimple(m)
+		if indent != "" {
+			table = indent + strings.Replace(strings.TrimSuffix(table, "\n"), "\n", "\n"+indent, -1) + "\n"
+		}
+	} else {
+		table = rstGrid(m, len(indent))
+	}
+	b.WriteString(table)
+	if m.Caption != "" {
+		fmt.Fprintf(&b, "\n%s\n", rstEscape(m.Caption))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// rstGrid returns the grid table drawn by the Drawer. The header is
+// separated by '=', all other lines use '-'.
+func rstGrid(m *Model, indent int) string {
+	t := modelTable(m, rstText)
+	t.paints = nil
+	t.Title, t.Caption = "", ""
+	t.HeadStyle = NewStyle('+', '=', '|', true)
+	t.BodyStyle = NewStyle('+', '-', '|', true)
+	if len(m.Header) == 0 {
+		// the Drawer skips the top line of the body below a header style
+		t.HeadStyle = NewStyle(' ', ' ', '|', true)
+	}
+	t.HeadOnlyBottomLine = false
+	t.BottomLine = true
+	t.Padding = []row.Padding{{Left: 1, Right: 1}}
+	t.Indent = indent
+	// a span covers the padding and the line between its columns
+	t.columnCap = rstWidths(m, t.rows, 3, t.spans)
+	lines := strings.SplitAfter(t.String()+"\n", "\n")
+	last := -1
+	if len(m.Header) > 0 {
+		for i, l := range lines {
+			if rstHeadLine(l) {
+				last = i
+			}
+		}
+	}
+	// the cells are single lines, so every other line above the last is
+	// a border, which has no text
+	for i := 0; i < last; i += 2 {
+		lines[i] = strings.Replace(lines[i], "=", "-", -1)
+	}
+	return strings.Join(lines, "")
+}
+
+// rstHeadLine reports whether the line l of a grid table is a border
+// drawn by the header style, which has only + and = after the indent.
+func rstHeadLine(l string) bool {
+	l = strings.TrimSpace(l)
+	return strings.HasPrefix(l, "+") && strings.Trim(l, "+=") == ""
+}
+
+// rstSimple returns the simple table of the model.
+func rstSimple(m *Model) string {
+	rows := m.rows()
+	texts := make([][]string, len(rows))
+	for i, cells := range rows {
+		texts[i] = make([]string, len(cells))
+		for j, c := range cells {
+			if !c.Merged {
+				texts[i][j] = rstText(c)
+			}
+		}
+	}
+	// body spans are dropped, the text is written into the first cell
+	var spans []row.Span
+	for i, cells := range m.Header {
+		for j, c := range cells {
+			if !c.Merged && c.Cols > 1 {
+				spans = append(spans, row.Span{Row: i, Col: j, Rows: 1, Cols: c.Cols})
+			}
+		}
+	}
+	widths := rstWidths(m, texts, 2, spans)
+	border := make([]string, len(widths))
+	for j, n := range widths {
+		border[j] = strings.Repeat("=", n)
+	}
+	var b strings.Builder
+	b.WriteString(strings.Join(border, "  ") + "\n")
+	for i, cells := range rows {
+		if i == len(m.Header) && i > 0 {
+			b.WriteString(strings.Join(border, "  ") + "\n")
+		}
+		var parts, under []string
+		for j := 0; j < len(cells); j++ {
+			s := texts[i][j]
+			if j == 0 && strings.TrimSpace(s) == "" {
+				// a blank first column continues the row above
+				s = `\`
+			}
+			n := widths[j]
+			cols := 1
+			if i < len(m.Header) && !cells[j].Merged && cells[j].Cols > 1 {
+				cols = cells[j].Cols
+			}
+			for k := j + 1; k < j+cols && k < len(widths); k++ {
+				n += 2 + widths[k]
+			}
+			parts = append(parts, m.Columns[j].Align.Pad(s, n))
+			under = append(under, strings.Repeat("-", n))
+			j += cols - 1
+		}
+		b.WriteString(strings.TrimRight(strings.Join(parts, "  "), " ") + "\n")
+		if i < len(m.Header)-1 && len(under) < len(widths) {
+			b.WriteString(strings.Join(under, "  ") + "\n")
+		}
+	}
+	b.WriteString(strings.Join(border, "  ") + "\n")
+	return b.String()
+}
+
+// rstWidths returns the widths of the columns, which are at least as wide
+// as the escaped texts. RST does not allow to cut the text of a cell. sep
+// is the width between two columns.
+func rstWidths(m *Model, texts [][]string, sep int, spans []row.Span) row.ColumnCap {
+	c := row.NewPaddedColumnCap(texts, make([]row.Padding, len(m.Columns)), sep, spans...)
+	for j, col := range m.Columns {
+		if j < len(c) && col.Width > c[j] {
+			c[j] = col.Width
+		}
+	}
+	return c
+}
+
+// rstText returns the escaped text of c, links are written as anonymous
+// hyperlink references.
+func rstText(c Cell) string {
+	s := rstEscape(c.Text)
+	if c.Link != "" && s != "" {
+		s = fmt.Sprintf("`%s <%s>`__", s, c.Link)
+	}
+	return s
+}
+
+var rstReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	`*`, `\*`,
+	`_`, `\_`,
+	`|`, `\|`,
+	"\r\n", " ",
+	"\n", " ",
+)
+
+// rstEscape escapes the inline markup characters of reStructuredText in s.
+func rstEscape(s string) string {
+	return rstReplacer.Replace(s)
+}
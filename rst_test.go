@@ -0,0 +1,78 @@
+package table
+
+import "testing"
+
+func TestRST_grid(t *testing.T) {
+	ta := newModelTable()
+	ta.Caption = "c*"
+	ta.Renderer = RST{}
+	exp := ".. table:: T\n\n" +
+		"   +------------------+-----------+\n" +
+		"   | Name             |   Latency |\n" +
+		"   |                  +-----+-----+\n" +
+		"   |                  | p50 | p99 |\n" +
+		"   +==================+=====+=====+\n" +
+		"   | `a <http://a>`__ |   1 |  95 |\n" +
+		"   +------------------+-----+-----+\n" +
+		"   | Total            |   1 |  95 |\n" +
+		"   +------------------+-----+-----+\n" +
+		"\nc\\*\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestRST_gridNoHeader(t *testing.T) {
+	ta, _ := New(false, [][]string{{"a|b", "x"}, {"", "y_"}}...)
+	ta.Renderer = RST{}
+	exp := "+------+-----+\n" +
+		"| a\\|b | x   |\n" +
+		"+------+-----+\n" +
+		"|      | y\\_ |\n" +
+		"+------+-----+\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestRST_simple(t *testing.T) {
+	ta := newModelTable()
+	ta.Renderer = RST{Simple: true}
+	exp := ".. table:: T\n\n" +
+		"   ================  ===  ===\n" +
+		"   Name               Latency\n" +
+		"   ----------------  --------\n" +
+		"   \\                 p50  p99\n" +
+		"   ================  ===  ===\n" +
+		"   `a <http://a>`__    1   95\n" +
+		"   Total               1   95\n" +
+		"   ================  ===  ===\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestRST_simpleNoHeader(t *testing.T) {
+	ta, _ := New(false, [][]string{{"a*", "x"}, {"", "`y`"}}...)
+	ta.Renderer = RST{Simple: true}
+	exp := "===  =====\n" +
+		"a\\*  x\n" +
+		"\\    \\`y\\`\n" +
+		"===  =====\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestRST_gridBodyEquals(t *testing.T) {
+	ta, _ := New(true, [][]string{{"a", "b"}, {"x+=1", "+="}}...)
+	ta.Renderer = RST{}
+	exp := "+------+----+\n" +
+		"| a    | b  |\n" +
+		"+======+====+\n" +
+		"| x+=1 | += |\n" +
+		"+------+----+\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
@@ -0,0 +1,164 @@
+package table
+
+import (
+	"github.com/thibran/table/draw"
+	"github.com/thibran/table/row"
+)
+
+// drawChunks draws a table wider than MaxWidth as several stacked tables.
+// Every table repeats the number column and the first KeyColumns columns,
+// followed by as many of the remaining columns as fit. The title is only
+// drawn above the first and the caption below the last table.
+func (t *Table) drawChunks(d *draw.Drawer) (int64, error) {
+	recs, err := t.r.ReadAll()
+	if err != nil {
+		return 0, err
+	}
+	head := recs
+	if len(head) > t.HeaderRows {
+		head = head[:t.HeaderRows]
+	}
+	l := t.layout(d, head)
+	chunks := t.chunks(d)
+	var n int64
+	for k, cols := range chunks {
+		cd := t.newDraw(d.Writer)
+		cd.ColumnCap = pickInts(d.ColumnCap, cols)
+		cd.Spans = d.Spans.Project(cols)
+		cd.HeadCols = d.HeadCols
+		if k > 0 {
+			cd.Title = ""
+			cd.WriteBreak("\n")
+		}
+		if k < len(chunks)-1 {
+			cd.Caption = ""
+		}
+		cl := l
+		cl.cols = cols
+		cl.spans = d.Spans
+		cl.pads = make([]row.Padding, len(cols))
+		for i, j := range cols {
+			cl.pads[i] = l.pads[j]
+		}
+		if t.rows != nil {
+			t.widenSpans(cd, recs, cl)
+		}
+		cd.WriteTitle()
+		for i, b := range recs {
+			t.writeRow(cd, i, b, cl, i == 0, i == t.HeaderRows)
+		}
+		cd.WriteBottomBodyLine()
+		cd.WriteCaption()
+		n += cd.BytesWritten
+		if cd.Err != nil {
+			return n, cd.Err
+		}
+	}
+	return n, nil
+}
+
+// widenSpans widens the columns of a chunk, so that the text of spans
+// cut by the chunk still fits.
+func (t *Table) widenSpans(d *draw.Drawer, recs [][]string, l layout) {
+	rows := make([][]string, len(recs))
+	for i, b := range recs {
+		if t.Numbering != nil {
+			b = append([]string{""}, b...)
+		}
+		rows[i] = pickStrings(b, l.source(i))
+	}
+	c := row.NewPaddedColumnCap(rows, l.pads, d.ColumnSep(), d.Spans...)
+	for j := range d.ColumnCap {
+		if t.isFixed(l.cols[j] - d.HeadCols) {
+			continue
+		}
+		if j < len(c) && c[j] > d.ColumnCap[j] {
+			d.ColumnCap[j] = c[j]
+		}
+	}
+}
+
+// chunks groups the columns of d so that every group is at most MaxWidth
+// wide. Every group starts with the key columns and contains at least one
+// other column.
+func (t *Table) chunks(d *draw.Drawer) [][]int {
+	n := len(d.ColumnCap)
+	keys := d.HeadCols + t.KeyColumns
+	if keys > n {
+		keys = n
+	}
+	key := make([]int, keys)
+	for j := range key {
+		key[j] = j
+	}
+	width := func(cols []int) int {
+		w := *d
+		w.ColumnCap = pickInts(d.ColumnCap, cols)
+		return w.Width()
+	}
+	var chunks [][]int
+	cols := key
+	for j := keys; j < n; j++ {
+		c := append(append([]int(nil), cols...), j)
+		if len(cols) > keys && width(c) > t.MaxWidth {
+			chunks = append(chunks, cols)
+			c = append(append([]int(nil), key...), j)
+		}
+		cols = c
+	}
+	return append(chunks, cols)
+}
+
+// source returns for every drawn column of row i the index of the cell
+// it shows, or nil if all columns are drawn. A span cut by the chunk
+// shows the text of its top-left cell in its first drawn column.
+func (l layout) source(i int) []int {
+	if l.cols == nil {
+		return nil
+	}
+	src := make([]int, len(l.cols))
+	for k, j := range l.cols {
+		src[k] = j
+		sp, ok := l.spans.At(i, j)
+		if ok && sp.Row == i && (k == 0 || l.cols[k-1] < sp.Col) {
+			src[k] = sp.Col
+		}
+	}
+	return src
+}
+
+func pickInts(a []int, src []int) []int {
+	p := make([]int, len(src))
+	for k, j := range src {
+		if j < len(a) {
+			p[k] = a[j]
+		}
+	}
+	return p
+}
+
+func pickStrings(a []string, src []int) []string {
+	if a == nil {
+		return nil
+	}
+	p := make([]string, len(src))
+	for k, j := range src {
+		if j < len(a) {
+			p[k] = a[j]
+		}
+	}
+	return p
+}
+
+func pickPaints(a []row.Paint, src []int) []row.Paint {
+	if a == nil {
+		return nil
+	}
+	p := make([]row.Paint, len(src))
+	for k, j := range src {
+		if j < len(a) {
+			p[k] = a[j]
+		}
+	}
+	return p
+}
@@ -0,0 +1,69 @@
+package table
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestTable_maxWidth(t *testing.T) {
+	ta, _ := New(true, [][]string{
+		{"Name", "Alpha", "Beta", "Gamma"},
+		{"a", "1111111", "2222222", "3333333"},
+		{"bb", "1", "2", "3"}}...)
+	ta.MaxWidth = 24
+	ta.KeyColumns = 1
+	ta.Caption = "C"
+	var b bytes.Buffer
+	n, err := ta.WriteTo(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := b.String()
+	exp := "Name Alpha   Beta    \n=====================\na    1111111 2222222 \nbb   1       2       \n\n" +
+		"Name Gamma   \n=============\na    3333333 \nbb   3       \nC            "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+	if n != int64(len(exp)) {
+		t.Errorf("written bytes should be %d but are %d", len(exp), n)
+	}
+}
+
+func TestTable_maxWidthFits(t *testing.T) {
+	ta, _ := New(false, [][]string{{"a", "b"}}...)
+	ta.MaxWidth = 10
+	s := ta.String()
+	exp := "a b "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestTable_maxWidthSpan(t *testing.T) {
+	ta, _ := New(true, [][]string{
+		{"Latency", "", ""},
+		{"1", "2", "3"}}...)
+	ta.Span(0, 0, 1, 3)
+	ta.MaxWidth = 2
+	s := ta.String()
+	exp := "Latency \n========\n1       \n\nLatency \n========\n2       \n\nLatency \n========\n3       "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestTable_maxWidthTruncate(t *testing.T) {
+	ta, _ := ReadFrom(strings.NewReader("k,a,bb\n"), false, []int{1, 1, 1})
+	ta.MaxWidth = 4
+	ta.KeyColumns = 1
+	var cols []int
+	ta.OnTruncate = func(i, j int, text string) {
+		cols = append(cols, j)
+	}
+	ta.WriteTo(io.Discard)
+	if len(cols) != 1 || cols[0] != 2 {
+		t.Errorf("should be [2] but is %v", cols)
+	}
+}
@@ -0,0 +1,199 @@
+package table
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sheetKind is the type of a spreadsheet cell.
+type sheetKind int
+
+const (
+	sheetString sheetKind = iota
+	sheetNumber
+	sheetDate
+	sheetDateTime
+)
+
+// sheetDateLayouts are the layouts of the texts written as dates.
+var sheetDateLayouts = []struct {
+	layout string
+	kind   sheetKind
+}{
+	{"2006-01-02", sheetDate},
+	{"2006-01-02 15:04:05", sheetDateTime},
+	{"2006-01-02T15:04:05", sheetDateTime},
+	{time.RFC3339, sheetDateTime},
+}
+
+// sheetValue returns the kind of the text s and the time of dates. Texts
+// like 007, which are no JSON numbers, stay strings.
+func sheetValue(s string) (sheetKind, time.Time) {
+	if jsonNumber.MatchString(s) {
+		return sheetNumber, time.Time{}
+	}
+	for _, l := range sheetDateLayouts {
+		if t, err := time.Parse(l.layout, s); err == nil {
+			return l.kind, t
+		}
+	}
+	return sheetString, time.Time{}
+}
+
+// excelEpoch is day 0 of the serial dates of spreadsheets.
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// excelSerial returns the days since excelEpoch of the wall clock of t.
+func excelSerial(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(),
+		t.Nanosecond(), time.UTC)
+	return wall.Sub(excelEpoch).Hours() / 24
+}
+
+// sheetAggregates are the functions tried for footer cells, in order.
+var sheetAggregates = []struct {
+	name string
+	fn   func(v []float64) float64
+}{
+	{"SUM", func(v []float64) float64 {
+		var s float64
+		for _, x := range v {
+			s += x
+		}
+		return s
+	}},
+	{"AVERAGE", func(v []float64) float64 {
+		var s float64
+		for _, x := range v {
+			s += x
+		}
+		return s / float64(len(v))
+	}},
+	{"MIN", func(v []float64) float64 {
+		m := v[0]
+		for _, x := range v[1:] {
+			m = math.Min(m, x)
+		}
+		return m
+	}},
+	{"MAX", func(v []float64) float64 {
+		m := v[0]
+		for _, x := range v[1:] {
+			m = math.Max(m, x)
+		}
+		return m
+	}},
+	{"COUNT", func(v []float64) float64 { return float64(len(v)) }},
+}
+
+// sheetFormula returns the function of the footer number text in column
+// j, which is aggregates[j] if aggregates is not nil and guessed by
+// sheetAggregate otherwise. It returns "" if the value is written, which
+// is always the case without body rows.
+func sheetFormula(m *Model, j int, text string, aggregates []string) string {
+	if len(m.Body) == 0 {
+		return ""
+	}
+	if aggregates == nil {
+		return sheetAggregate(m, j, text)
+	}
+	if j < len(aggregates) {
+		return strings.ToUpper(aggregates[j])
+	}
+	return ""
+}
+
+// checkAggregates returns an error if a function of aggregates is not one
+// of sheetAggregates.
+func checkAggregates(aggregates []string) error {
+	for j, a := range aggregates {
+		if a == "" {
+			continue
+		}
+		known := false
+		for _, f := range sheetAggregates {
+			known = known || strings.EqualFold(a, f.name)
+		}
+		if !known {
+			return fmt.Errorf("unknown function %q of column %d", a, j)
+		}
+	}
+	return nil
+}
+
+// sheetAggregate returns the function, which computes the number text of
+// a footer cell in column j from the numbers of the body, e.g. SUM. It
+// returns "" unless exactly one function gives the number.
+func sheetAggregate(m *Model, j int, text string) string {
+	v, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return ""
+	}
+	var nums []float64
+	for _, cells := range m.Body {
+		if j >= len(cells) || cells[j].Merged {
+			continue
+		}
+		if k, _ := sheetValue(cells[j].Text); k != sheetNumber {
+			continue
+		}
+		if x, err := strconv.ParseFloat(cells[j].Text, 64); err == nil {
+			nums = append(nums, x)
+		}
+	}
+	if len(nums) == 0 {
+		return ""
+	}
+	// the tolerance only covers the rounding errors of floats
+	tol := 1e-9 * math.Max(1, math.Abs(v))
+	var name string
+	for _, a := range sheetAggregates {
+		if math.Abs(a.fn(nums)-v) > tol {
+			continue
+		}
+		if name != "" {
+			return ""
+		}
+		name = a.name
+	}
+	return name
+}
+
+// columnName returns the letters of column j of a sheet, A for 0.
+func columnName(j int) string {
+	var s string
+	for j++; j > 0; j = (j - 1) / 26 {
+		s = string(rune('A'+(j-1)%26)) + s
+	}
+	return s
+}
+
+// sheetName returns the title as name of a sheet, without the characters
+// spreadsheets do not allow and at most 31 runes long. It is Sheet1 if
+// the title is empty.
+func sheetName(title string) string {
+	s := strings.TrimSpace(strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) || r < ' ' {
+			return -1
+		}
+		return r
+	}, title))
+	if r := []rune(s); len(r) > 31 {
+		s = string(r[:31])
+	}
+	if s == "" {
+		return "Sheet1"
+	}
+	return s
+}
+
+// xmlEscape returns s escaped as XML text or attribute value.
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,60 @@
+package table
+
+import "testing"
+
+func TestSheetValue(t *testing.T) {
+	for s, exp := range map[string]sheetKind{"1.5": sheetNumber, "-3e2": sheetNumber,
+		"007": sheetString, "2024-01-02": sheetDate, "2024-01-02 10:20:30": sheetDateTime,
+		"2024-01-02T10:20:30+02:00": sheetDateTime, "2024-13-02": sheetString, "x": sheetString} {
+		if k, _ := sheetValue(s); k != exp {
+			t.Errorf("%q should be kind %d, is %d", s, exp, k)
+		}
+	}
+}
+
+func TestExcelSerial(t *testing.T) {
+	_, d := sheetValue("2024-01-02 12:00:00")
+	if v := excelSerial(d); v != 45293.5 {
+		t.Errorf("serial should be 45293.5, is %v", v)
+	}
+	_, d = sheetValue("2024-01-02T12:00:00+05:00")
+	if v := excelSerial(d); v != 45293.5 {
+		t.Errorf("the wall clock should be kept, serial is %v", v)
+	}
+}
+
+func TestSheetAggregate(t *testing.T) {
+	m := &Model{Body: [][]Cell{{{Text: "1"}}, {{Text: "2"}}, {{Text: "x"}}, {{Text: "4"}}}}
+	for text, exp := range map[string]string{"7": "SUM", "2.33": "", "1": "MIN",
+		"4.0": "MAX", "3": "COUNT", "5": "", "x": ""} {
+		if a := sheetAggregate(m, 0, text); a != exp {
+			t.Errorf("%q should be %q, is %q", text, exp, a)
+		}
+	}
+	m = &Model{Body: [][]Cell{{{Text: "1"}}, {{Text: "2"}}}}
+	for text, exp := range map[string]string{"2": "", "1.5": "AVERAGE", "3": "SUM"} {
+		if a := sheetAggregate(m, 0, text); a != exp {
+			t.Errorf("%q should be %q, is %q", text, exp, a)
+		}
+	}
+	if a := sheetAggregate(&Model{}, 0, "1"); a != "" {
+		t.Errorf("an empty body should have no aggregate, is %q", a)
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for j, exp := range map[int]string{0: "A", 25: "Z", 26: "AA", 701: "ZZ", 702: "AAA"} {
+		if s := columnName(j); s != exp {
+			t.Errorf("column %d should be %s, is %s", j, exp, s)
+		}
+	}
+}
+
+func TestSheetName(t *testing.T) {
+	for title, exp := range map[string]string{"": "Sheet1", " [*] ": "Sheet1",
+		"Q1: a/b": "Q1 ab", "abcdefghijklmnopqrstuvwxyz0123456789": "abcdefghijklmnopqrstuvwxyz01234"} {
+		if s := sheetName(title); s != exp {
+			t.Errorf("%q should be %q, is %q", title, exp, s)
+		}
+	}
+}
@@ -1,4 +1,4 @@
-package main
+package table
 
 // Style of the line or edge between vertical & horizontal lines.
 type Style struct {
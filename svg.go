@@ -0,0 +1,326 @@
+package table
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// SVG renders the model as SVG image of the table drawn by ASCII. Every
+// rune is placed on a grid of a monospaced font, so lines and alignment
+// look as in the terminal. The paints of the cells set the color, weight
+// and background of the text, links are written as SVG links.
+type SVG struct {
+	HeadStyle  *Style // style of the header, the default of Table if nil
+	BodyStyle  *Style // style of the body, the default of Table if nil
+	FontSize   int    // font size in pixels, 14 if zero
+	Foreground string // color of unpainted text, black if empty
+	Background string // color of the image, transparent if empty
+}
+
+// Render the model m.
+func (s SVG) Render(w io.Writer, m *Model) error {
+	var text strings.Builder
+	a := ASCII{HeadStyle: s.HeadStyle, BodyStyle: s.BodyStyle, Hyperlinks: true}
+	if err := a.Render(&text, m); err != nil {
+		return err
+	}
+	size := float64(s.FontSize)
+	if size <= 0 {
+		size = 14
+	}
+	fg, bg := s.Foreground, s.Background
+	if fg == "" {
+		fg = "#000000"
+	}
+	// cell width and line height of the grid, the margin is one cell
+	cw, lh := size*0.6, size*1.2
+	lines := strings.Split(strings.TrimSuffix(text.String(), "\n"), "\n")
+	var cols int
+	parsed := make([][]svgRun, len(lines))
+	for i, l := range lines {
+		parsed[i] = parseANSI(l)
+		if n := svgWidth(parsed[i]); n > cols {
+			cols = n
+		}
+	}
+	width, height := float64(cols+2)*cw, float64(len(lines))*lh+2*cw
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %[1]s %[2]s" font-family="monospace" font-size="%s">`+"\n",
+		svgNum(width), svgNum(height), svgNum(size))
+	if bg != "" {
+		fmt.Fprintf(&b, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", xmlEscape(bg))
+	}
+	for i, runs := range parsed {
+		y := cw + float64(i)*lh
+		for _, r := range runs {
+			if c := r.background(fg, bg); c != "" {
+				fmt.Fprintf(&b, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"/>`+"\n",
+					svgNum(cw+float64(r.col)*cw), svgNum(y), svgNum(float64(r.runes())*cw),
+					svgNum(lh), xmlEscape(c))
+			}
+		}
+	}
+	fmt.Fprintf(&b, `<g fill="%s" xml:space="preserve">`+"\n", xmlEscape(fg))
+	for i, runs := range parsed {
+		// the baseline leaves room for descenders below the text
+		y := cw + float64(i)*lh + size
+		var spans []string
+		for _, r := range runs {
+			if strings.TrimSpace(r.text) == "" && !r.underline {
+				continue
+			}
+			span := fmt.Sprintf(`<tspan x="%s"%s>%s</tspan>`, svgNum(cw+float64(r.col)*cw),
+				r.attrs(fg, bg), xmlEscape(r.text))
+			if r.link != "" {
+				span = fmt.Sprintf(`<a href="%s">%s</a>`, xmlEscape(r.link), span)
+			}
+			spans = append(spans, span)
+		}
+		if len(spans) > 0 {
+			fmt.Fprintf(&b, `<text y="%s">%s</text>`+"\n", svgNum(y), strings.Join(spans, ""))
+		}
+	}
+	b.WriteString("</g>\n</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// svgRun is text of a line with the same attributes.
+type svgRun struct {
+	svgAttr
+	text string
+	col  int // column of the first rune in the line
+	link string
+}
+
+func (r svgRun) runes() int {
+	return utf8.RuneCountInString(r.text)
+}
+
+// svgAttr are the attributes set by SGR escape sequences.
+type svgAttr struct {
+	fg, bg                                string // colors, default if empty
+	bold, dim, italic, underline, inverse bool
+}
+
+// colors returns the text and background color of a, inverse swaps them.
+func (a svgAttr) colors(fg, bg string) (string, string) {
+	if !a.inverse {
+		return a.fg, a.bg
+	}
+	f, b := a.bg, a.fg
+	if f == "" {
+		f = bg
+		if f == "" {
+			f = "#ffffff"
+		}
+	}
+	if b == "" {
+		b = fg
+	}
+	return f, b
+}
+
+// background returns the background color of a, empty for the default.
+func (a svgAttr) background(fg, bg string) string {
+	_, b := a.colors(fg, bg)
+	return b
+}
+
+// attrs returns the XML attributes of a tspan.
+func (a svgAttr) attrs(fg, bg string) string {
+	var s string
+	if f, _ := a.colors(fg, bg); f != "" {
+		s += fmt.Sprintf(` fill="%s"`, xmlEscape(f))
+	}
+	if a.bold {
+		s += ` font-weight="bold"`
+	}
+	if a.italic {
+		s += ` font-style="italic"`
+	}
+	if a.underline {
+		s += ` text-decoration="underline"`
+	}
+	if a.dim {
+		s += ` opacity="0.5"`
+	}
+	return s
+}
+
+// sgr applies the parameters of an SGR escape sequence.
+func (a *svgAttr) sgr(params string) {
+	p := strings.Split(params, ";")
+	for i := 0; i < len(p); i++ {
+		n, err := strconv.Atoi(p[i])
+		if err != nil {
+			n = 0 // an empty parameter resets like 0
+		}
+		switch {
+		case n == 0:
+			*a = svgAttr{}
+		case n == 1:
+			a.bold = true
+		case n == 2:
+			a.dim = true
+		case n == 3:
+			a.italic = true
+		case n == 4:
+			a.underline = true
+		case n == 7:
+			a.inverse = true
+		case n == 22:
+			a.bold, a.dim = false, false
+		case n == 23:
+			a.italic = false
+		case n == 24:
+			a.underline = false
+		case n == 27:
+			a.inverse = false
+		case n >= 30 && n <= 37:
+			a.fg = ansiColor(n - 30)
+		case n >= 90 && n <= 97:
+			a.fg = ansiColor(n - 90 + 8)
+		case n >= 40 && n <= 47:
+			a.bg = ansiColor(n - 40)
+		case n >= 100 && n <= 107:
+			a.bg = ansiColor(n - 100 + 8)
+		case n == 39:
+			a.fg = ""
+		case n == 49:
+			a.bg = ""
+		case n == 38 || n == 48:
+			var c string
+			c, i = extendedColor(p, i+1)
+			if n == 38 {
+				a.fg = c
+			} else {
+				a.bg = c
+			}
+		}
+	}
+}
+
+// extendedColor parses the 5;n or 2;r;g;b parameters starting at p[i]
+// and returns the color and the index of the last parameter used.
+func extendedColor(p []string, i int) (string, int) {
+	num := func(k int) int {
+		if k >= len(p) {
+			return 0
+		}
+		n, _ := strconv.Atoi(p[k])
+		return n
+	}
+	switch {
+	case i < len(p) && p[i] == "5":
+		return ansiColor(num(i + 1)), i + 1
+	case i < len(p) && p[i] == "2":
+		return fmt.Sprintf("#%02x%02x%02x", num(i+1)&255, num(i+2)&255, num(i+3)&255), i + 3
+	}
+	return "", i
+}
+
+// ansiPalette are the 16 colors of xterm.
+var ansiPalette = [16]string{
+	"#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5",
+	"#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+// ansiColor returns color n of the 256 color palette of xterm.
+func ansiColor(n int) string {
+	switch {
+	case n < 0 || n > 255:
+		return ""
+	case n < 16:
+		return ansiPalette[n]
+	case n < 232:
+		n -= 16
+		level := func(v int) int {
+			if v == 0 {
+				return 0
+			}
+			return 55 + v*40
+		}
+		return fmt.Sprintf("#%02x%02x%02x", level(n/36), level(n/6%6), level(n%6))
+	}
+	g := 8 + (n-232)*10
+	return fmt.Sprintf("#%02x%02x%02x", g, g, g)
+}
+
+// parseANSI splits a line into runs of the same attributes. SGR and OSC 8
+// sequences are interpreted, other escape sequences are dropped.
+func parseANSI(line string) []svgRun {
+	var (
+		runs []svgRun
+		cur  svgRun
+		col  int
+		text strings.Builder
+	)
+	flush := func() {
+		if text.Len() > 0 {
+			cur.text = text.String()
+			runs = append(runs, cur)
+			text.Reset()
+		}
+	}
+	for i := 0; i < len(line); {
+		if line[i] != '\x1b' || i+1 >= len(line) {
+			r, n := utf8.DecodeRuneInString(line[i:])
+			text.WriteRune(r)
+			col++
+			i += n
+			continue
+		}
+		switch line[i+1] {
+		case '[':
+			end := strings.IndexFunc(line[i+2:], func(r rune) bool { return r >= 0x40 && r <= 0x7e })
+			if end < 0 {
+				i = len(line)
+				continue
+			}
+			flush()
+			if line[i+2+end] == 'm' {
+				cur.sgr(line[i+2 : i+2+end])
+			}
+			i += 2 + end + 1
+		case ']':
+			end := strings.Index(line[i:], "\x1b\\")
+			if end < 0 {
+				i = len(line)
+				continue
+			}
+			flush()
+			osc := line[i+2 : i+end]
+			if strings.HasPrefix(osc, "8;") {
+				if k := strings.Index(osc[2:], ";"); k >= 0 {
+					cur.link = osc[2+k+1:]
+				}
+			}
+			i += end + 2
+		default:
+			i += 2
+		}
+		cur.col = col
+	}
+	flush()
+	return runs
+}
+
+// svgWidth returns the number of runes of a line.
+func svgWidth(runs []svgRun) int {
+	if len(runs) == 0 {
+		return 0
+	}
+	last := runs[len(runs)-1]
+	return last.col + last.runes()
+}
+
+// svgNum formats f with at most two decimals.
+func svgNum(f float64) string {
+	return strconv.FormatFloat(math.Round(f*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,55 @@
+package table
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/thibran/table/row"
+)
+
+func TestSVG(t *testing.T) {
+	ta, _ := New(true, [][]string{{"a", "b"}, {"1<", "x"}}...)
+	ta.Rules = row.Rules{{Column: 1, Match: row.Equal("x"), Paint: row.Bold.Add(row.BgRed)}}
+	ta.Link(1, 0, "http://a?b&c\x01")
+	ta.Renderer = SVG{FontSize: 10, Background: "white"}
+	exp := `<svg xmlns="http://www.w3.org/2000/svg" width="42" height="48" viewBox="0 0 42 48" font-family="monospace" font-size="10">
+<rect width="100%" height="100%" fill="white"/>
+<rect x="24" y="30" width="12" height="12" fill="#cd0000"/>
+<g fill="#000000" xml:space="preserve">
+<text y="16"><tspan x="6">a  b </tspan></text>
+<text y="28"><tspan x="6">=====</tspan></text>
+<text y="40"><a href="http://a?b&amp;c�"><tspan x="6">1&lt;</tspan></a><tspan x="24" font-weight="bold">x </tspan></text>
+</g>
+</svg>
+`
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestParseANSI(t *testing.T) {
+	runs := parseANSI("a\x1b[1;31mb\x1b[0m \x1b]8;;u\x1b\\l\x1b]8;;\x1b\\\x1b[38;5;196;48;2;1;2;3mx\x1b[7my")
+	exp := []svgRun{
+		{text: "a"},
+		{svgAttr: svgAttr{fg: "#cd0000", bold: true}, text: "b", col: 1},
+		{text: " ", col: 2},
+		{text: "l", col: 3, link: "u"},
+		{svgAttr: svgAttr{fg: "#ff0000", bg: "#010203"}, text: "x", col: 4},
+		{svgAttr: svgAttr{fg: "#ff0000", bg: "#010203", inverse: true}, text: "y", col: 5},
+	}
+	if !reflect.DeepEqual(runs, exp) {
+		t.Errorf("\n\nshould be:\n%+v\n\nbut is:\n%+v", exp, runs)
+	}
+	if f, b := runs[5].colors("#000", ""); f != "#010203" || b != "#ff0000" {
+		t.Errorf("inverse colors should be swapped: %s %s", f, b)
+	}
+}
+
+func TestAnsiColor(t *testing.T) {
+	for n, exp := range map[int]string{1: "#cd0000", 9: "#ff0000", 16: "#000000",
+		21: "#0000ff", 231: "#ffffff", 232: "#080808", 256: ""} {
+		if c := ansiColor(n); c != exp {
+			t.Errorf("color %d should be %q, is %q", n, exp, c)
+		}
+	}
+}
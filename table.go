@@ -1,8 +1,9 @@
-package main
+package table
 
 import (
 	"bytes"
 	"encoding/csv"
+	"errors"
 	"fmt"
 	"io"
 
@@ -17,74 +18,193 @@ type Table struct {
 	TopLine            bool // if true, a line is drawn above the header or first entry
 	BottomLine         bool // if true, a line is drawn below the last entry
 	HeadOnlyBottomLine bool
-	hasHeader          bool
-	postfixSpace       uint8         // whitespace after every cell
-	columnCap          row.ColumnCap // max characters in column
-	r                  *csv.Reader
+	HeaderRows         int        // number of header rows, usually 0 or 1
+	FooterRows         int        // number of footer rows at the end, e.g. totals
+	Title              string     // centered above the table
+	Caption            string     // left aligned below the table
+	FrameText          bool       // if true, Title and Caption are enclosed in the border
+	Numbering          *Numbering // if not nil, a column with row numbers is added
+	Stripe             row.Paint  // paint of every second body row
+	LineEvery          int        // if > 0, a body line is drawn only every n body rows
+	Rules              row.Rules  // conditional formatting of body cells
+	Indent             int        // whitespace in front of every line
+	Gap                int        // whitespace between columns
+	Hyperlinks         bool       // if true, links are written as OSC 8 escapes
+	MaxWidth           int        // if > 0, wider tables are split into column chunks
+	KeyColumns         int        // leading columns repeated in every column chunk
+
+	// Padding of the cells per column, columns without an entry use the
+	// last one. The default is one space right of the text.
+	Padding []row.Padding
+
+	// Truncate describes per column how too long cells are cut, columns
+	// without an entry use the last one. The default is row.DefaultTruncate.
+	Truncate []row.Truncate
+
+	// Align describes per column how the text is aligned within the
+	// column, columns without an entry use the last one. The default
+	// is row.AlignLeft.
+	Align []row.Align
+
+	// OnTruncate is called for every cut cell with its row and column
+	// index and its text before it was cut.
+	OnTruncate func(i, j int, text string)
+
+	// Renderer writes the table in another format, e.g. Markdown. If nil,
+	// the table is drawn as text.
+	Renderer Renderer
+
+	// RowPaint returns the paint of body row i, which is added to Stripe.
+	RowPaint func(i int, cells []string) row.Paint
+
+	columnCap row.ColumnCap // max characters in column, without padding
+	spans     row.Spans     // merged cells
+	links     map[pos]string
+	rows      [][]string    // rows passed to New, nil for ReadFrom
+	fixed     []bool        // columns whose width is set, not computed from rows
+	paints    [][]row.Paint // paint of every cell, replaces Rules if not nil
+	r         reader
 }
 
+// pos of a cell, row i and column j.
+type pos struct {
+	i, j int
+}
+
+var errSpanSize = errors.New("span must cover at least one row and column")
+var errSpanOverlap = errors.New("span overlaps an already merged cell")
+var errSpanHeader = errors.New("span must not cover header and body rows")
+
 // ReadFrom reader r rows unitl io.EOF. If header is true, the first row is
 // treated as header. The number of columns and the column-width-per-rune
 // is specified by runesPerColumn. The returned csv.Reader can be used to
 // set e.g. the delimiter rune for the passed io.Reader.
 func ReadFrom(r io.Reader, header bool, runesPerColumn []int) (*Table, *csv.Reader) {
-	postfixSpace := uint8(1)
-	c := row.ConvRunesPerColumn(runesPerColumn, postfixSpace)
-	rd := csv.NewReader(r)
-	return newTable(rd, header, c, postfixSpace), rd
+	o, _ := newOptions([]Option{WithHeader(headerRows(header))})
+	o.widths = runesPerColumn
+	t := o.readTable(r)
+	return t, t.r.(*csv.Reader)
 }
 
-// New table from slice of rows.
+// New table from slice of rows, rows without cells are skipped.
 // If hasHeader is true the first row is treated as header-row.
 func New(hasHeader bool, rows ...[]string) (*Table, error) {
-	if len(rows) == 0 {
+	var kept [][]string
+	for _, r := range rows {
+		if len(r) > 0 {
+			kept = append(kept, r)
+		}
+	}
+	if len(kept) == 0 {
 		return new(Table), nil
 	}
-	var b bytes.Buffer
-	for _, row := range rows {
-		for i, cell := range row {
-			fmt.Fprintf(&b, "%q", cell)
-			if i < len(row)-1 {
-				b.WriteRune(',')
-			}
+	return NewWith(kept, WithHeader(headerRows(hasHeader)))
+}
+
+// headerRows returns the number of header rows of a table with or
+// without header.
+func headerRows(hasHeader bool) int {
+	if hasHeader {
+		return 1
+	}
+	return 0
+}
+
+// reader of the rows of a table, implemented by csv.Reader.
+type reader interface {
+	Read() ([]string, error)
+	ReadAll() ([][]string, error)
+}
+
+// rowReader reads the rows passed to New.
+type rowReader struct {
+	rows [][]string
+}
+
+// newReader returns a reader of copies of rows.
+func newReader(rows [][]string) *rowReader {
+	return &rowReader{rows: rows}
+}
+
+func (r *rowReader) Read() ([]string, error) {
+	if len(r.rows) == 0 {
+		return nil, io.EOF
+	}
+	b := append([]string(nil), r.rows[0]...)
+	r.rows = r.rows[1:]
+	return b, nil
+}
+
+func (r *rowReader) ReadAll() ([][]string, error) {
+	var rows [][]string
+	for {
+		b, err := r.Read()
+		if err == io.EOF {
+			return rows, nil
 		}
-		b.WriteRune('\n')
+		rows = append(rows, b)
+	}
+}
+
+// Span merges the cell at row i, column j with its neighbours, so that
+// it covers rows rows and cols columns. Only the text of the top-left
+// cell is drawn, the text of the other cells is ignored. The row index
+// counts the header row as well.
+func (t *Table) Span(i, j, rows, cols int) error {
+	if rows < 1 || cols < 1 {
+		return errSpanSize
+	}
+	if i < 0 || j < 0 || j+cols > len(t.columnCap) ||
+		(t.rows != nil && i >= len(t.rows)) {
+		return fmt.Errorf("span at row %d, column %d with %d columns is out of range",
+			i, j, cols)
+	}
+	if i < t.HeaderRows && i+rows > t.HeaderRows {
+		return errSpanHeader
+	}
+	s := row.Span{Row: i, Col: j, Rows: rows, Cols: cols}
+	if t.spans.Overlaps(s) {
+		return errSpanOverlap
+	}
+	t.spans = append(t.spans, s)
+	return nil
+}
+
+// Link the cell at row i, column j to url. The link is only drawn if
+// Hyperlinks is true, the width of the cell is that of its text.
+func (t *Table) Link(i, j int, url string) {
+	if t.links == nil {
+		t.links = make(map[pos]string)
 	}
-	postfixSpace := uint8(1)
-	r := csv.NewReader(&b)
-	c := row.NewColumnCap(rows, postfixSpace)
-	return newTable(r, hasHeader, c, postfixSpace), nil
+	t.links[pos{i, j}] = url
 }
 
 // WriteTo returns the bytes written.
 func (t *Table) WriteTo(w io.Writer) (int64, error) {
+	t.rewind()
+	if t.Renderer != nil {
+		return t.render(w)
+	}
 	d := t.newDraw(w)
 	return t.drawRow(d)
 }
 
 func (t *Table) String() string {
 	var buf bytes.Buffer
-	d := t.newDraw(io.Writer(&buf))
-	if _, err := t.drawRow(d); err != nil {
+	if _, err := t.WriteTo(&buf); err != nil {
 		panic(err)
 	}
 	return buf.String()
 }
 
-func newTable(
-	r *csv.Reader,
-	hasHeader bool,
-	c row.ColumnCap,
-	postfixSpace uint8) *Table {
+// newTable returns a table with the default settings.
+func newTable() *Table {
 	return &Table{
 		HeadStyle:          StyleSquare(),
 		BodyStyle:          StyleEmpty(),
-		columnCap:          c,
 		TopLine:            true,
 		HeadOnlyBottomLine: true,
-		postfixSpace:       postfixSpace,
-		r:                  r,
-		hasHeader:          hasHeader,
+		Padding:            []row.Padding{{Right: 1}},
 	}
 }
 
@@ -98,47 +218,320 @@ func (t *Table) newDraw(w io.Writer) *draw.Drawer {
 		HeadLineH: t.HeadStyle.lineH,
 		HeadLineV: t.HeadStyle.lineV,
 
-		LineBodyTop: t.TopLine && !t.BodyStyle.isEmptyH(),
-		LineBodyBot: t.BottomLine && !t.BodyStyle.isEmptyH(),
-		LineBodyV:   t.BodyStyle.vLines && !t.BodyStyle.isAllEmpty(),
+		LineBodyTop:   t.TopLine && (!t.BodyStyle.isEmptyH() || t.LineEvery > 0),
+		LineBodyBot:   t.BottomLine && !t.BodyStyle.isEmptyH(),
+		LineBodyV:     t.BodyStyle.vLines && !t.BodyStyle.isAllEmpty(),
+		LineBodyEvery: t.LineEvery,
 
 		BodyEdge:  t.BodyStyle.edge,
 		BodyLineH: t.BodyStyle.lineH,
 		BodyLineV: t.BodyStyle.lineV,
 
 		ColumnCap: t.columnCap,
+		HeadRows:  t.HeaderRows,
+		Title:     t.Title,
+		Caption:   t.Caption,
+		FrameText: t.FrameText,
+		Indent:    t.Indent,
+		Gap:       t.Gap,
 		Writer:    w,
 	}
 }
 
 func (t *Table) drawRow(d *draw.Drawer) (int64, error) {
-	var i int
-	for {
-		b, err := t.r.Read()
+	if t.MaxWidth > 0 {
+		return t.drawChunks(d)
+	}
+	return t.draw(d, nil)
+}
+
+// draw the table, if p is not nil the table is split into pages.
+func (t *Table) draw(d *draw.Drawer, p *Pager) (int64, error) {
+	head, err := t.readHead()
+	if err != nil {
+		return d.BytesWritten, err
+	}
+	l := t.layout(d, head)
+	pages := p.count(t.bodyRows())
+	page := 1
+	d.WriteTitle()
+	for i := 0; ; i++ {
+		var b []string
+		if i < len(head) {
+			b = head[i]
+		} else {
+			b, err = t.r.Read()
+		}
 		if err == io.EOF {
-			d.WriteBottomBodyLine()
 			break
 		}
 		if err != nil {
 			return d.BytesWritten, err
 		}
-		isHeader := i == 0 && t.hasHeader
-		firstBodyRow := t.isFirstBodyRow(i)
-		row := row.New(t.columnCap, []string(b), t.postfixSpace)
-		if i != 0 {
-			d.WriteNewline()
+		first := i == 0
+		firstBody := i == t.HeaderRows
+		if p.isBreak(i - t.HeaderRows) {
+			d.WriteBottomBodyLine()
+			d.WriteFooter(p.footer(page, pages))
+			d.WriteBreak(p.separator())
+			page++
+			d.WriteTitle()
+			for k, h := range head {
+				d.SetRow(k)
+				t.writeRow(d, k, h, l, k == 0, false)
+			}
+			d.SetRow(i)
+			first = len(head) == 0
+			firstBody = true
 		}
-		d.Row(row, isHeader, firstBodyRow)
-		i++
+		t.writeRow(d, i, b, l, first, firstBody)
+	}
+	d.WriteBottomBodyLine()
+	d.WriteCaption()
+	if p != nil {
+		d.WriteFooter(p.footer(page, pages))
 	}
 	return d.BytesWritten, d.Err
 }
 
-func (t *Table) isFirstBodyRow(i int) bool {
-	if i == 0 && !t.hasHeader {
-		return true
-	} else if i == 1 && t.hasHeader {
-		return true
+// writeRow draws row i. First is true for the first row of a page,
+// firstBody for the first body row of a page.
+func (t *Table) writeRow(d *draw.Drawer, i int, b []string, l layout,
+	first, firstBody bool) {
+	isHeader := i < t.HeaderRows
+	b = append([]string(nil), b...)
+	d.RowPaint = t.rowPaint(i, b)
+	d.CellPaint = t.cellPaint(i, b)
+	d.CellLink = t.cellLink(i, len(b))
+	if t.Numbering != nil {
+		n := t.Numbering.cell(i, t.HeaderRows, l.numWidth)
+		if t.isFooter(i, len(t.rows)) {
+			n = ""
+		}
+		b = append([]string{n}, b...)
+		d.CellPaint = append([]row.Paint{""}, d.CellPaint...)
+		d.CellLink = append([]string{""}, d.CellLink...)
+	}
+	src := l.source(i)
+	if src != nil {
+		b = pickStrings(b, src)
+		d.CellPaint = pickPaints(d.CellPaint, src)
+		d.CellLink = pickStrings(d.CellLink, src)
+	}
+	row := t.newRow(d, i, b, l.pads, src)
+	if !first {
+		d.WriteNewline()
+	}
+	d.Row(row, isHeader, firstBody)
+}
+
+// isFooter returns true if row i of a table with n rows is a footer row.
+func (t *Table) isFooter(i, n int) bool {
+	return t.FooterRows > 0 && i >= t.HeaderRows && i >= n-t.FooterRows
+}
+
+// bodyRows returns the number of body rows or -1 if unknown.
+func (t *Table) bodyRows() int {
+	if t.rows == nil {
+		return -1
+	}
+	return len(t.rows) - t.HeaderRows
+}
+
+// newRow builds row i, b contains the number column if any. If src is
+// not nil, b only contains the columns of a chunk, see layout.source.
+func (t *Table) newRow(d *draw.Drawer, i int, b []string, pads []row.Padding,
+	src []int) row.Row {
+	offset := 0
+	if t.Numbering != nil {
+		offset = 1
+	}
+	// column of the table drawn as cell k, -1 for the number column
+	col := func(k int) int {
+		if src != nil {
+			return src[k] - offset
+		}
+		return k - offset
+	}
+	tr := make([]row.Truncate, len(b))
+	al := make([]row.Align, len(b))
+	for k := range b {
+		tr[k] = row.DefaultTruncate
+		if j := col(k); j >= 0 {
+			tr[k] = t.truncate(j)
+			al[k] = t.align(j)
+		}
+	}
+	r, cut := row.NewAligned(d.RowCap(), append([]string(nil), b...), pads, tr, al)
+	for k := range cut {
+		if cut[k] && t.OnTruncate != nil {
+			t.OnTruncate(i, col(k), b[k])
+		}
+	}
+	return r
+}
+
+// layout of the drawn columns.
+type layout struct {
+	pads     []row.Padding // padding of every column
+	numWidth int           // width of the numbers in the numbering column
+
+	// cols are the drawn columns of a column chunk, nil if all columns
+	// are drawn, and spans the merged cells of the whole table.
+	cols  []int
+	spans row.Spans
+}
+
+// layout sets the ColumnCap and Spans of d.
+func (t *Table) layout(d *draw.Drawer, head [][]string) layout {
+	d.Spans = headerSpans(head, t.spans)
+	pads := make([]row.Padding, len(t.columnCap))
+	for j := range pads {
+		pads[j] = t.padding(j)
+	}
+	c := t.columnCap.Padded(pads)
+	if t.rows != nil {
+		w := row.NewPaddedColumnCap(t.ruled(t.rows), pads, d.ColumnSep(), d.Spans...)
+		for j := range w {
+			if j < len(c) && t.isFixed(j) {
+				w[j] = c[j]
+			}
+		}
+		c = w
+	}
+	var numWidth int
+	if t.Numbering != nil {
+		numWidth = t.Numbering.width(t.bodyRows())
+		p := t.padding(0)
+		c, d.Spans = t.Numbering.prepend(c, d.Spans, numWidth, len(head), p)
+		pads = append([]row.Padding{p}, pads...)
+		d.HeadCols = 1
+	}
+	d.ColumnCap = c
+	return layout{pads: pads, numWidth: numWidth}
+}
+
+// isFixed returns true if the width of column j is set.
+func (t *Table) isFixed(j int) bool {
+	return j >= 0 && j < len(t.fixed) && t.fixed[j]
+}
+
+// padding of column j.
+func (t *Table) padding(j int) row.Padding {
+	switch {
+	case len(t.Padding) == 0:
+		return row.Padding{}
+	case j < len(t.Padding):
+		return t.Padding[j]
+	}
+	return t.Padding[len(t.Padding)-1]
+}
+
+// truncate returns the truncation of column j.
+func (t *Table) truncate(j int) row.Truncate {
+	switch {
+	case len(t.Truncate) == 0:
+		return row.DefaultTruncate
+	case j < len(t.Truncate):
+		return t.Truncate[j]
+	}
+	return t.Truncate[len(t.Truncate)-1]
+}
+
+// align returns the alignment of column j.
+func (t *Table) align(j int) row.Align {
+	switch {
+	case len(t.Align) == 0:
+		return row.AlignLeft
+	case j < len(t.Align):
+		return t.Align[j]
+	}
+	return t.Align[len(t.Align)-1]
+}
+
+// rowPaint returns the paint of row i, header rows are not painted.
+func (t *Table) rowPaint(i int, cells []string) row.Paint {
+	i -= t.HeaderRows
+	if i < 0 {
+		return ""
+	}
+	var p row.Paint
+	if i%2 == 1 {
+		p = t.Stripe
+	}
+	if t.RowPaint != nil {
+		p = p.Add(t.RowPaint(i, cells))
+	}
+	return p
+}
+
+// cellPaint applies the rules to the cells of row i and returns the
+// paint of every cell. Header rows are not formatted.
+func (t *Table) cellPaint(i int, cells []string) []row.Paint {
+	if t.paints != nil && i < len(t.paints) {
+		return t.paints[i]
+	}
+	if i < t.HeaderRows || len(t.Rules) == 0 {
+		return nil
+	}
+	f := t.Rules.Apply(cells)
+	p := make([]row.Paint, len(f))
+	for j := range f {
+		p[j] = f[j].Paint
+	}
+	return p
+}
+
+// ruled returns the rows with the texts of the rules applied, as they
+// are drawn, so the columns are wide enough for the replaced texts.
+func (t *Table) ruled(rows [][]string) [][]string {
+	if t.paints != nil || len(t.Rules) == 0 {
+		return rows
+	}
+	r := make([][]string, len(rows))
+	for i, b := range rows {
+		if i >= t.HeaderRows {
+			b = append([]string(nil), b...)
+			t.Rules.Apply(b)
+		}
+		r[i] = b
+	}
+	return r
+}
+
+// cellLink returns the link targets of the n cells of row i.
+func (t *Table) cellLink(i, n int) []string {
+	if !t.Hyperlinks || len(t.links) == 0 {
+		return nil
+	}
+	l := make([]string, n)
+	for j := range l {
+		l[j] = t.links[pos{i, j}]
+	}
+	return l
+}
+
+// rewind starts reading the rows passed to New again, so the table can
+// be written more than once. Tables created by ReadFrom are read once.
+func (t *Table) rewind() {
+	if t.rows != nil {
+		t.r = newReader(t.rows)
+	}
+}
+
+// readHead reads the header rows, they are needed to merge group names
+// before the first header row is drawn.
+func (t *Table) readHead() ([][]string, error) {
+	var head [][]string
+	for i := 0; i < t.HeaderRows; i++ {
+		b, err := t.r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		head = append(head, b)
 	}
-	return false
+	return head, nil
 }
@@ -1,4 +1,4 @@
-package main
+package table
 
 import (
 	"bytes"
@@ -6,6 +6,8 @@ import (
 	"strings"
 	"sync"
 	"testing"
+
+	"github.com/thibran/table/row"
 )
 
 func TestTable_defaults(t *testing.T) {
@@ -77,7 +79,7 @@ func TestTable_empty(t *testing.T) {
 	if err != nil {
 		t.Fail()
 	}
-	ta.String()
+	_ = ta.String()
 }
 
 func TestWriteTo_default(t *testing.T) {
@@ -213,3 +215,261 @@ func TestReadFrom_cutCell(t *testing.T) {
 		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
 	}
 }
+
+func TestSpan_groupedHeader(t *testing.T) {
+	ta, _ := New(true, [][]string{
+		{"Name", "Latency", ""},
+		{"foo", "1", "2"}}...)
+	if err := ta.Span(0, 1, 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	ta.HeadStyle = NewStyle('+', '=', '|', true)
+	ta.BodyStyle = NewStyle('+', '-', '|', true)
+	ta.HeadOnlyBottomLine = false
+	ta.BottomLine = true
+	s := ta.String()
+	exp := "+=====+========+\n|Name |Latency |\n+=====+==+=====+\n|foo  |1 |2    |\n+-----+--+-----+"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestSpan_title(t *testing.T) {
+	ta, _ := New(false, [][]string{
+		{"A long title"},
+		{"a", "b"}}...)
+	if err := ta.Span(0, 0, 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	s := ta.String()
+	exp := "A long title \na b          "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestSpan_errors(t *testing.T) {
+	ta, _ := New(true, [][]string{
+		{"h1", "h2"},
+		{"a1", "a2"},
+		{"b1", "b2"}}...)
+	if err := ta.Span(0, 0, 1, 0); err != errSpanSize {
+		t.Errorf("should be %v but is %v", errSpanSize, err)
+	}
+	if err := ta.Span(1, 1, 1, 2); err == nil {
+		t.Error("span out of range should fail")
+	}
+	if err := ta.Span(0, 0, 2, 1); err != errSpanHeader {
+		t.Errorf("should be %v but is %v", errSpanHeader, err)
+	}
+	if err := ta.Span(1, 0, 2, 1); err != nil {
+		t.Error(err)
+	}
+	if err := ta.Span(2, 0, 1, 2); err != errSpanOverlap {
+		t.Errorf("should be %v but is %v", errSpanOverlap, err)
+	}
+}
+
+func TestTable_headerRows(t *testing.T) {
+	ta, _ := New(true, [][]string{
+		{"Name", "Latency", "Latency", "Latency"},
+		{"", "p50", "p95", "p99"},
+		{"foo", "1", "2", "3"}}...)
+	ta.HeaderRows = 2
+	s := ta.String()
+	exp := "Name Latency     \n     ============\n     p50 p95 p99 \n=================\nfoo  1   2   3   "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestTable_headerRowsVlines(t *testing.T) {
+	ta, _ := New(true, [][]string{
+		{"Name", "Latency", "Latency"},
+		{"", "p50", "p99"},
+		{"foo", "1", "2"}}...)
+	ta.HeaderRows = 2
+	ta.HeadStyle = NewStyle('+', '=', '|', true)
+	ta.HeadOnlyBottomLine = false
+	s := ta.String()
+	exp := "+=====+=========+\n|Name |Latency  |\n|     +====+====+\n|     |p50 |p99 |\n+=====+====+====+\n foo   1    2    "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestTable_titleCaption(t *testing.T) {
+	ta, _ := New(true, [][]string{
+		{"Name", "Size"},
+		{"foo", "1"}}...)
+	ta.Title = "Files"
+	ta.Caption = "in bytes"
+	s := ta.String()
+	exp := "  Files   \nName Size \n==========\nfoo  1    \nin bytes  "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestTable_titleFrame(t *testing.T) {
+	ta, _ := New(true, [][]string{
+		{"Name", "Size"},
+		{"foo", "1"}}...)
+	ta.Title = "Files"
+	ta.FrameText = true
+	ta.HeadStyle = NewStyle('+', '=', '|', true)
+	s := ta.String()
+	exp := "+===========+\n|   Files   |\n+=====+=====+\n|Name |Size |\n+=====+=====+\n foo   1     "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%s\n\nbut is:\n%s", exp, s)
+	}
+}
+
+func TestTable_stripe(t *testing.T) {
+	ta, _ := New(true, [][]string{
+		{"h"},
+		{"a"},
+		{"b"},
+		{"c"}}...)
+	ta.Stripe = row.BgGray
+	s := ta.String()
+	exp := "h \n==\na \n\x1b[100mb \x1b[0m\nc "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestTable_rowPaint(t *testing.T) {
+	ta, _ := New(false, [][]string{
+		{"ok"},
+		{"FAIL"}}...)
+	ta.RowPaint = func(i int, cells []string) row.Paint {
+		if cells[0] == "FAIL" {
+			return row.Red
+		}
+		return ""
+	}
+	s := ta.String()
+	exp := "ok   \n\x1b[31mFAIL \x1b[0m"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestTable_lineEvery(t *testing.T) {
+	ta, _ := New(false, [][]string{{"a"}, {"b"}, {"c"}}...)
+	ta.LineEvery = 2
+	s := ta.String()
+	exp := "a \nb \n  \nc "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestTable_rules(t *testing.T) {
+	ta, _ := New(true, [][]string{
+		{"Name", "Load"},
+		{"a", "95"},
+		{"b", ""}}...)
+	ta.Rules = row.Rules{
+		{Column: 1, Match: row.Greater(90), Paint: row.Red},
+		{Column: 1, Match: row.Empty, Paint: row.Dim, Text: "-"},
+	}
+	s := ta.String()
+	exp := "Name Load \n==========\na    \x1b[31m95   \x1b[0m\nb    \x1b[2m-    \x1b[0m"
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestTable_rulesText(t *testing.T) {
+	ta, _ := New(true, [][]string{
+		{"Name", "Load"},
+		{"a", "95"},
+		{"b", ""}}...)
+	ta.Rules = row.Rules{{Column: 1, Match: row.Empty, Text: "missing"}}
+	s := ta.String()
+	exp := "Name Load    \n=============\na    95      \nb    missing "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestTable_padding(t *testing.T) {
+	ta, _ := New(true, [][]string{
+		{"Name", "Size"},
+		{"foo", "1"}}...)
+	ta.Padding = []row.Padding{{Left: 1, Right: 1}}
+	ta.Indent = 2
+	ta.Gap = 1
+	s := ta.String()
+	exp := "   Name   Size \n  =============\n   foo    1    "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestTable_truncate(t *testing.T) {
+	text := "/usr/local/bin/tool,abcdef\n"
+	ta, _ := ReadFrom(strings.NewReader(text), false, []int{8, 4})
+	ta.Truncate = []row.Truncate{
+		{Mode: row.TruncateMiddle, Ellipsis: "…"},
+		{Mode: row.TruncateEnd, Ellipsis: "…"},
+	}
+	var cut []int
+	ta.OnTruncate = func(i, j int, text string) {
+		cut = append(cut, j)
+	}
+	s := ta.String()
+	exp := "/usr…ool abc… "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+	if len(cut) != 2 || cut[0] != 0 || cut[1] != 1 {
+		t.Errorf("should be [0 1] but is %v", cut)
+	}
+}
+
+func TestTable_link(t *testing.T) {
+	ta, _ := New(false, [][]string{{"PR 1", "x"}}...)
+	ta.Link(0, 0, "https://example.com/1")
+	ta.Hyperlinks = true
+	s := ta.String()
+	exp := "\x1b]8;;https://example.com/1\x1b\\PR 1\x1b]8;;\x1b\\ x "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestTable_linkDisabled(t *testing.T) {
+	ta, _ := New(false, [][]string{{"PR 1", "x"}}...)
+	ta.Link(0, 0, "https://example.com/1")
+	s := ta.String()
+	exp := "PR 1 x "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestNew_keepsCells(t *testing.T) {
+	rows := [][]string{{`a"b`, "c\nd", `\n`}}
+	ta, _ := New(false, rows...)
+	b, err := ta.r.Read()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for j := range rows[0] {
+		if b[j] != rows[0][j] {
+			t.Errorf("should be %q but is %q", rows[0][j], b[j])
+		}
+	}
+}
+
+func TestNew_skipsEmptyRows(t *testing.T) {
+	ta, _ := New(true, []string{"h", "i"}, []string{"a", "b"}, nil, []string{"c", "d"})
+	s := ta.String()
+	exp := "h i \n====\na b \nc d "
+	if s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
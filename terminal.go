@@ -0,0 +1,129 @@
+package table
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// Key pressed on a Terminal, either a rune or one of the special keys.
+type Key rune
+
+// Special keys.
+const (
+	KeyUp Key = -1 - iota
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyPageUp
+	KeyPageDown
+	KeyHome
+	KeyEnd
+	KeyEnter
+	KeyEsc
+	KeyBackspace
+)
+
+// Terminal is the screen and keyboard a View is drawn on.
+type Terminal interface {
+	io.Writer
+	Size() (width, height int)
+	ReadKey() (Key, error) // returns io.EOF if there are no more keys
+}
+
+type terminal struct {
+	in            *bufio.Reader
+	out           io.Writer
+	width, height int
+}
+
+// NewTerminal returns a Terminal of the given size, which reads ANSI key
+// sequences from in and writes to out. The caller has to switch the
+// terminal into raw mode, e.g. with `stty raw -echo`. Ctrl-C and Ctrl-D
+// are reported as io.EOF.
+func NewTerminal(in io.Reader, out io.Writer, width, height int) Terminal {
+	return &terminal{
+		in:     bufio.NewReader(in),
+		out:    out,
+		width:  width,
+		height: height,
+	}
+}
+
+func (t *terminal) Size() (int, int) {
+	return t.width, t.height
+}
+
+// Write translates line breaks to "\r\n", because output processing is
+// disabled in raw mode.
+func (t *terminal) Write(p []byte) (int, error) {
+	_, err := t.out.Write(bytes.Replace(p, []byte("\n"), []byte("\r\n"), -1))
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (t *terminal) ReadKey() (Key, error) {
+	r, _, err := t.in.ReadRune()
+	if err != nil {
+		return 0, err
+	}
+	switch r {
+	case 3, 4:
+		return 0, io.EOF
+	case '\r', '\n':
+		return KeyEnter, nil
+	case 8, 127:
+		return KeyBackspace, nil
+	case '\x1b':
+		return t.readEscape()
+	}
+	return Key(r), nil
+}
+
+// readEscape reads the rest of an escape sequence like "\x1b[A". A single
+// escape without buffered bytes following it is the escape key.
+func (t *terminal) readEscape() (Key, error) {
+	if t.in.Buffered() == 0 {
+		return KeyEsc, nil
+	}
+	r, _, err := t.in.ReadRune()
+	if err != nil {
+		return KeyEsc, nil
+	}
+	if r != '[' && r != 'O' {
+		t.in.UnreadRune()
+		return KeyEsc, nil
+	}
+	var seq []rune
+	for {
+		r, _, err = t.in.ReadRune()
+		if err != nil {
+			return KeyEsc, nil
+		}
+		seq = append(seq, r)
+		if r >= 0x40 && r <= 0x7e {
+			break
+		}
+	}
+	switch string(seq) {
+	case "A":
+		return KeyUp, nil
+	case "B":
+		return KeyDown, nil
+	case "C":
+		return KeyRight, nil
+	case "D":
+		return KeyLeft, nil
+	case "H", "1~", "7~":
+		return KeyHome, nil
+	case "F", "4~", "8~":
+		return KeyEnd, nil
+	case "5~":
+		return KeyPageUp, nil
+	case "6~":
+		return KeyPageDown, nil
+	}
+	return t.ReadKey()
+}
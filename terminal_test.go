@@ -0,0 +1,48 @@
+package table
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestTerminal_readKey(t *testing.T) {
+	term := NewTerminal(strings.NewReader("a\x1b[A\x1b[6~\r\x7f\x1b[2~ä"), nil, 80, 24)
+	exp := []Key{'a', KeyUp, KeyPageDown, KeyEnter, KeyBackspace, 'ä'}
+	for _, e := range exp {
+		k, err := term.ReadKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if k != e {
+			t.Errorf("should be %d but is %d", e, k)
+		}
+	}
+	if _, err := term.ReadKey(); err != io.EOF {
+		t.Errorf("should be io.EOF but is %v", err)
+	}
+}
+
+func TestTerminal_esc(t *testing.T) {
+	term := NewTerminal(strings.NewReader("\x1b"), nil, 80, 24)
+	if k, _ := term.ReadKey(); k != KeyEsc {
+		t.Errorf("should be %d but is %d", KeyEsc, k)
+	}
+}
+
+func TestTerminal_ctrlC(t *testing.T) {
+	term := NewTerminal(strings.NewReader("\x03"), nil, 80, 24)
+	if _, err := term.ReadKey(); err != io.EOF {
+		t.Errorf("should be io.EOF but is %v", err)
+	}
+}
+
+func TestTerminal_write(t *testing.T) {
+	var b bytes.Buffer
+	term := NewTerminal(nil, &b, 80, 24)
+	n, _ := term.Write([]byte("a\nb"))
+	if b.String() != "a\r\nb" || n != 3 {
+		t.Errorf("should be %q but is %q", "a\r\nb", b.String())
+	}
+}
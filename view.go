@@ -0,0 +1,447 @@
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/thibran/table/draw"
+	"github.com/thibran/table/row"
+)
+
+// View is an interactive viewer of a table. The header rows, the number
+// column and the first KeyColumns columns of the table stay in place, while
+// the other rows and columns are scrolled. Rows numbered with
+// Numbering.Original keep their number when they are sorted. Spans, title
+// and caption are not drawn.
+//
+// Keys:
+//
+//	up, down, page up, page down, home, end  scroll the rows
+//	left, right                              scroll the columns
+//	1 - 9                                    sort by the n-th drawn column, again to reverse
+//	+, -                                     resize the first scrolled column
+//	/                                        search, enter jumps to the first match
+//	n                                        jump to the next match
+//	q, esc                                   quit
+type View struct {
+	t        *Table
+	head     [][]string
+	body     [][]string
+	source   []int // index of every body row in the source, sorted with body
+	width    []int // content width of every column
+	top      int   // first drawn body row
+	left     int   // first drawn column after the key columns
+	page     int   // number of body rows of the last drawn screen
+	cols     []int // columns of the last drawn screen
+	sortCol  int   // column the body is sorted by, -1 if unsorted
+	sortDesc bool
+	query    string // search text
+	typing   bool   // true while the search text is entered
+}
+
+// NewView reads all rows of t. Tables created by ReadFrom can not be
+// drawn afterwards.
+func NewView(t *Table) (*View, error) {
+	t.rewind()
+	recs, err := t.r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	h := t.HeaderRows
+	if h > len(recs) {
+		h = len(recs)
+	}
+	v := &View{
+		t:       t,
+		head:    recs[:h],
+		body:    recs[h:],
+		width:   append([]int(nil), t.columnCap...),
+		page:    1,
+		sortCol: -1,
+	}
+	v.source = make([]int, len(v.body))
+	for i := range v.source {
+		v.source[i] = i
+		if n := t.Numbering; n != nil && n.Original && i < len(n.Source) {
+			v.source[i] = n.Source[i]
+		}
+	}
+	if t.rows != nil {
+		w := row.NewColumnCap(t.ruled(recs), 0)
+		for j := range w {
+			if j < len(v.width) && t.isFixed(j) {
+				w[j] = v.width[j]
+			}
+		}
+		v.width = w
+	}
+	return v, nil
+}
+
+// Run draws the view on term and handles its keys, until the view is
+// closed or term returns io.EOF.
+func (v *View) Run(term Terminal) error {
+	for {
+		w, h := term.Size()
+		if err := v.Draw(term, w, h); err != nil {
+			return err
+		}
+		k, err := term.ReadKey()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !v.Key(k) {
+			return nil
+		}
+	}
+}
+
+// Key handles key k and returns false if the view should be closed.
+func (v *View) Key(k Key) bool {
+	if v.typing {
+		v.search(k)
+		return true
+	}
+	switch k {
+	case 'q', KeyEsc:
+		return false
+	case KeyUp, 'k':
+		v.scroll(-1)
+	case KeyDown, 'j':
+		v.scroll(1)
+	case KeyPageUp:
+		v.scroll(-v.page)
+	case KeyPageDown, ' ':
+		v.scroll(v.page)
+	case KeyHome, 'g':
+		v.scroll(-len(v.body))
+	case KeyEnd, 'G':
+		v.scroll(len(v.body))
+	case KeyLeft, 'h':
+		if v.left > 0 {
+			v.left--
+		}
+	case KeyRight, 'l':
+		if v.keys()+v.left+1 < len(v.width) {
+			v.left++
+		}
+	case '+':
+		v.resize(1)
+	case '-':
+		v.resize(-1)
+	case '/':
+		v.typing = true
+		v.query = ""
+	case 'n':
+		v.next(v.top + 1)
+	}
+	if k >= '1' && k <= '9' {
+		j := int(k - '1')
+		if v.t.Numbering != nil {
+			j--
+		}
+		switch {
+		case j < 0:
+			v.sort(numberColumn)
+		case j < len(v.cols):
+			v.sort(v.cols[j])
+		}
+	}
+	return true
+}
+
+// Draw writes a screen of width and height runes to w.
+func (v *View) Draw(w io.Writer, width, height int) error {
+	var buf bytes.Buffer
+	d := v.t.newDraw(&buf)
+	d.Title, d.Caption = "", ""
+	d.LineBodyBot = false
+	cols := v.columns(d, width)
+	v.cols = cols
+	d.ColumnCap = v.columnCap(cols)
+	pads := make([]row.Padding, len(cols))
+	tr := make([]row.Truncate, len(cols))
+	al := make([]row.Align, len(cols))
+	for k, j := range cols {
+		pads[k] = v.t.padding(j)
+		tr[k] = v.t.truncate(j)
+		al[k] = v.t.align(j)
+	}
+	if v.t.Numbering != nil {
+		pads = append([]row.Padding{v.t.padding(0)}, pads...)
+		tr = append([]row.Truncate{row.DefaultTruncate}, tr...)
+		al = append([]row.Align{row.AlignLeft}, al...)
+		d.HeadCols = 1
+	}
+	headLines := 0
+	first := true
+	for i, b := range v.head {
+		d.CellPaint = make([]row.Paint, len(cols))
+		for k, j := range cols {
+			if j == v.keys()+v.left {
+				d.CellPaint[k] = row.Underline
+			}
+		}
+		d.CellPaint = v.numberPaint(d.CellPaint)
+		v.writeRow(d, i, first, b, cols, pads, tr, al)
+		first = false
+		headLines = strings.Count(buf.String(), "\n") + 1
+	}
+	end := v.top + height
+	if end > len(v.body) {
+		end = len(v.body)
+	}
+	for i := v.top; i < end; i++ {
+		b := append([]string(nil), v.body[i]...)
+		k := i + len(v.head)
+		d.RowPaint = v.t.rowPaint(k, b)
+		d.CellPaint = v.t.cellPaint(k, b)
+		if v.query != "" && !v.typing {
+			d.CellPaint = v.mark(d.CellPaint, b)
+		}
+		d.CellPaint = v.numberPaint(pickPaints(d.CellPaint, cols))
+		v.writeRow(d, k, first, b, cols, pads, tr, al)
+		first = false
+	}
+	lines := strings.Split(buf.String(), "\n")
+	if len(lines) > height-1 {
+		lines = lines[:height-1]
+	}
+	v.page = height - 1 - headLines
+	if v.page < 1 {
+		v.page = 1
+	}
+	s := "\x1b[H\x1b[2J" + strings.Join(lines, "\n") + "\n" + v.status(width)
+	_, err := io.WriteString(w, s)
+	return err
+}
+
+func (v *View) writeRow(d *draw.Drawer, i int, first bool, b []string, cols []int,
+	pads []row.Padding, tr []row.Truncate, al []row.Align) {
+	cells := pickStrings(b, cols)
+	if v.t.Numbering != nil {
+		cells = append([]string{v.number(i)}, cells...)
+	}
+	r, _ := row.NewAligned(d.RowCap(), cells, pads, tr, al)
+	if !first {
+		d.WriteNewline()
+	}
+	d.Row(r, i < len(v.head), i == len(v.head)+v.top)
+}
+
+// number returns the text of the number column of row i, which counts
+// the header rows.
+func (v *View) number(i int) string {
+	n := v.t.Numbering
+	switch {
+	case i == 0 && len(v.head) > 0:
+		return n.Title
+	case i < len(v.head):
+		return ""
+	case n.Original:
+		return fmt.Sprintf("%*d", v.numberWidth(), n.Start+v.source[i-len(v.head)])
+	}
+	return fmt.Sprintf("%*d", v.numberWidth(), n.Start+i-len(v.head))
+}
+
+// numberWidth returns the width of the numbers of the number column.
+func (v *View) numberWidth() int {
+	n := *v.t.Numbering
+	n.Source = v.source
+	return n.width(len(v.body))
+}
+
+// numberPaint returns the paints p of the drawn columns, preceded by that
+// of the number column if there is one.
+func (v *View) numberPaint(p []row.Paint) []row.Paint {
+	if v.t.Numbering == nil {
+		return p
+	}
+	return append([]row.Paint{""}, p...)
+}
+
+// keys returns the number of frozen columns.
+func (v *View) keys() int {
+	if v.t.KeyColumns > len(v.width) {
+		return len(v.width)
+	}
+	return v.t.KeyColumns
+}
+
+// columns returns the key columns followed by the scrolled columns, which
+// fit into width. At least one scrolled column is returned.
+func (v *View) columns(d *draw.Drawer, width int) []int {
+	var cols []int
+	for j := 0; j < v.keys(); j++ {
+		cols = append(cols, j)
+	}
+	for j := v.keys() + v.left; j < len(v.width); j++ {
+		c := append(cols, j)
+		w := *d
+		w.ColumnCap = v.columnCap(c)
+		if len(cols) > v.keys() && w.Width() > width {
+			break
+		}
+		cols = c
+	}
+	return cols
+}
+
+// columnCap returns the padded widths of cols, preceded by the number
+// column if there is one.
+func (v *View) columnCap(cols []int) row.ColumnCap {
+	c := make(row.ColumnCap, len(cols))
+	for k, j := range cols {
+		p := v.t.padding(j)
+		c[k] = v.width[j] + p.Left + p.Right
+	}
+	if v.t.Numbering != nil {
+		w := v.numberWidth()
+		if title := utf8.RuneCountInString(v.t.Numbering.Title); title > w {
+			w = title
+		}
+		p := v.t.padding(0)
+		c = append(row.ColumnCap{w + p.Left + p.Right}, c...)
+	}
+	return c
+}
+
+func (v *View) scroll(n int) {
+	v.top += n
+	if max := len(v.body) - v.page; v.top > max {
+		v.top = max
+	}
+	if v.top < 0 {
+		v.top = 0
+	}
+}
+
+// resize the first scrolled column by n runes.
+func (v *View) resize(n int) {
+	j := v.keys() + v.left
+	if j < len(v.width) && v.width[j]+n > 0 {
+		v.width[j] += n
+	}
+}
+
+// numberColumn is the sort column of the number column, which sorts the
+// body rows by their index in the source.
+const numberColumn = -2
+
+// sort the body by column j, numbers are compared by their value.
+// Sorting by the same column again reverses the order.
+func (v *View) sort(j int) {
+	v.sortDesc = v.sortCol == j && !v.sortDesc
+	v.sortCol = j
+	cell := func(i int) string {
+		if j < len(v.body[i]) {
+			return v.body[i][j]
+		}
+		return ""
+	}
+	order := make([]int, len(v.body))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		a, b = order[a], order[b]
+		if v.sortDesc {
+			a, b = b, a
+		}
+		if j == numberColumn {
+			return v.source[a] < v.source[b]
+		}
+		return less(cell(a), cell(b))
+	})
+	body := make([][]string, len(order))
+	source := make([]int, len(order))
+	for i, k := range order {
+		body[i], source[i] = v.body[k], v.source[k]
+	}
+	v.body, v.source = body, source
+}
+
+func less(a, b string) bool {
+	x, errX := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	y, errY := strconv.ParseFloat(strings.TrimSpace(b), 64)
+	if errX == nil && errY == nil {
+		return x < y
+	}
+	return a < b
+}
+
+// search handles key k while the search text is entered.
+func (v *View) search(k Key) {
+	switch k {
+	case KeyEnter:
+		v.typing = false
+		v.next(v.top)
+	case KeyEsc:
+		v.typing = false
+		v.query = ""
+	case KeyBackspace:
+		if _, n := utf8.DecodeLastRuneInString(v.query); n > 0 {
+			v.query = v.query[:len(v.query)-n]
+		}
+	default:
+		if k >= 0 {
+			v.query += string(rune(k))
+		}
+	}
+}
+
+// next scrolls to the first body row from row i on containing the search
+// text, the search continues at the first row.
+func (v *View) next(i int) {
+	for n := 0; n < len(v.body) && v.query != ""; n++ {
+		k := (i + n) % len(v.body)
+		for _, c := range v.body[k] {
+			if v.match(c) {
+				v.top = k
+				v.scroll(0)
+				return
+			}
+		}
+	}
+}
+
+func (v *View) match(s string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(v.query))
+}
+
+// mark adds Inverse to the paint of the cells containing the search text.
+func (v *View) mark(p []row.Paint, cells []string) []row.Paint {
+	m := make([]row.Paint, len(cells))
+	copy(m, p)
+	for j, c := range cells {
+		if v.match(c) {
+			m[j] = m[j].Add(row.Inverse)
+		}
+	}
+	return m
+}
+
+// status returns the last line of the screen, the search text while it is
+// entered, otherwise the drawn rows.
+func (v *View) status(width int) string {
+	if v.typing {
+		return "/" + v.query
+	}
+	end := v.top + v.page
+	if end > len(v.body) {
+		end = len(v.body)
+	}
+	s := fmt.Sprintf("%d-%d of %d", v.top+1, end, len(v.body))
+	if v.query != "" {
+		s += " /" + v.query
+	}
+	return row.TrimTextToMaxLength(s, width)
+}
@@ -0,0 +1,154 @@
+package table
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/thibran/table/row"
+)
+
+// fakeTerminal returns keys and records the drawn screens.
+type fakeTerminal struct {
+	bytes.Buffer
+	keys          []Key
+	width, height int
+}
+
+func (f *fakeTerminal) Size() (int, int) {
+	return f.width, f.height
+}
+
+func (f *fakeTerminal) ReadKey() (Key, error) {
+	if len(f.keys) == 0 {
+		return 0, io.EOF
+	}
+	k := f.keys[0]
+	f.keys = f.keys[1:]
+	return k, nil
+}
+
+// screen returns the last drawn screen.
+func (f *fakeTerminal) screen() string {
+	s := f.String()
+	return s[strings.LastIndex(s, "\x1b[2J")+4:]
+}
+
+func newTestView(t *testing.T) *View {
+	ta, _ := New(true, [][]string{
+		{"Name", "Alpha", "Beta", "Gamma"},
+		{"a", "10", "x", "3333333"},
+		{"bb", "9", "y", "3"},
+		{"c", "100", "z", "3"},
+		{"d", "1", "w", "3"}}...)
+	ta.KeyColumns = 1
+	v, err := NewView(ta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestView_run(t *testing.T) {
+	v := newTestView(t)
+	term := &fakeTerminal{width: 16, height: 5}
+	if err := v.Run(term); err != nil {
+		t.Fatal(err)
+	}
+	exp := "Name \x1b[4mAlpha \x1b[0mBeta \n================\na    10    x    \nbb   9     y    \n1-2 of 4        "
+	if s := term.screen(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestView_scroll(t *testing.T) {
+	v := newTestView(t)
+	term := &fakeTerminal{width: 16, height: 5, keys: []Key{KeyEnd, KeyRight, KeyRight}}
+	v.Run(term)
+	exp := "Name \x1b[4mGamma   \x1b[0m\n=============\nc    3       \nd    3       \n3-4 of 4        "
+	if s := term.screen(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestView_scrollNoHeader(t *testing.T) {
+	ta, _ := New(false, [][]string{{"a", "1"}, {"b", "2"}, {"c", "3"}, {"d", "4"}}...)
+	v, err := NewView(ta)
+	if err != nil {
+		t.Fatal(err)
+	}
+	term := &fakeTerminal{width: 10, height: 4, keys: []Key{KeyDown}}
+	v.Run(term)
+	exp := "b 2 \nc 3 \nd 4 \n2-4 of 4  "
+	if s := term.screen(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestView_sort(t *testing.T) {
+	v := newTestView(t)
+	term := &fakeTerminal{width: 16, height: 7, keys: []Key{'2'}}
+	v.Run(term)
+	if v.body[0][0] != "d" || v.body[3][0] != "c" {
+		t.Errorf("should be sorted by value: %v", v.body)
+	}
+	v.Key('2')
+	if v.body[0][0] != "c" {
+		t.Errorf("should be sorted in reverse: %v", v.body)
+	}
+}
+
+func TestView_rules(t *testing.T) {
+	v := newTestView(t)
+	v.t.Rules = row.Rules{{Column: 2, Match: row.Equal("x"), Text: "X"}}
+	term := &fakeTerminal{width: 16, height: 5}
+	v.Run(term)
+	if !strings.Contains(term.screen(), "X") || v.body[0][2] != "x" {
+		t.Errorf("the rules should only change the drawn text: %v", v.body)
+	}
+}
+
+func TestView_numbering(t *testing.T) {
+	v := newTestView(t)
+	v.t.Numbering = &Numbering{Start: 1, Title: "#", Original: true}
+	term := &fakeTerminal{width: 20, height: 5, keys: []Key{'3'}}
+	v.Run(term)
+	exp := "# Name \x1b[4mAlpha \x1b[0mBeta \n==================\n4 d    1     w    \n2 bb   9     y    \n1-2 of 4            "
+	if s := term.screen(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+	v.Key('1')
+	if v.body[0][0] != "a" || v.source[3] != 3 {
+		t.Errorf("the number column should restore the source order: %v %v", v.body, v.source)
+	}
+}
+
+func TestView_resize(t *testing.T) {
+	v := newTestView(t)
+	v.Key('+')
+	v.Key('-')
+	v.Key('-')
+	if v.width[1] != 4 {
+		t.Errorf("should be %d but is %d", 4, v.width[1])
+	}
+}
+
+func TestView_search(t *testing.T) {
+	v := newTestView(t)
+	term := &fakeTerminal{width: 16, height: 4, keys: []Key{'/', 'Y', KeyEnter}}
+	v.Run(term)
+	exp := "Name \x1b[4mAlpha \x1b[0mBeta \n================\nbb   9     \x1b[7my    \x1b[0m\n2-2 of 4 /Y     "
+	if s := term.screen(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestView_quit(t *testing.T) {
+	v := newTestView(t)
+	term := &fakeTerminal{width: 16, height: 5, keys: []Key{'q', KeyDown}}
+	v.Run(term)
+	if len(term.keys) != 1 {
+		t.Errorf("view should be closed after q")
+	}
+}
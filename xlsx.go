@@ -0,0 +1,218 @@
+package table
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// XLSX renders the model as Office Open XML workbook with a single sheet,
+// which is named by the title. The header rows are bold on a gray fill and
+// frozen, numbers and dates are written as typed cells and the width of
+// the columns is that of the model. Footer numbers are written as formula
+// of the body numbers of their column, see Aggregates. The caption is
+// written below the table.
+type XLSX struct {
+	// Aggregates are the functions of the footer numbers per column of
+	// the model: SUM, AVERAGE, MIN, MAX or COUNT, an empty one writes the
+	// number. If nil, the function is used, which alone gives exactly
+	// the footer number.
+	Aggregates []string
+}
+
+// Render the model m.
+func (x XLSX) Render(w io.Writer, m *Model) error {
+	if err := checkAggregates(x.Aggregates); err != nil {
+		return err
+	}
+	return writeZip(w, []zipFile{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, xmlEscape(sheetName(m.Title)))},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+		{"xl/worksheets/sheet1.xml", xlsxSheet(m, x.Aggregates)},
+	})
+}
+
+// zipFile is a file of a zip archive.
+type zipFile struct {
+	name, data string
+}
+
+// writeZip writes the files as zip archive to w. Files named mimetype
+// are stored uncompressed, as OpenDocument requires.
+func writeZip(w io.Writer, files []zipFile) error {
+	z := zip.NewWriter(w)
+	for _, f := range files {
+		h := &zip.FileHeader{Name: f.name, Method: zip.Deflate}
+		if f.name == "mimetype" {
+			h.Method = zip.Store
+		}
+		fw, err := z.CreateHeader(h)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(fw, f.data); err != nil {
+			return err
+		}
+	}
+	return z.Close()
+}
+
+// Styles of the cellXfs in xlsxStyles.
+const (
+	xlsxPlain = iota
+	xlsxHead
+	xlsxDate
+	xlsxDateTime
+	xlsxFoot
+)
+
+// xlsxStyle returns the style of a cell of the kind.
+func xlsxStyle(kind sheetKind, head, foot bool) int {
+	switch {
+	case head:
+		return xlsxHead
+	case kind == sheetDate:
+		return xlsxDate
+	case kind == sheetDateTime:
+		return xlsxDateTime
+	case foot:
+		return xlsxFoot
+	}
+	return xlsxPlain
+}
+
+// xlsxSheet returns the worksheet of the model, see XLSX.Aggregates.
+func xlsxSheet(m *Model, aggregates []string) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	head, body := len(m.Header), len(m.Body)
+	if head > 0 {
+		fmt.Fprintf(&b, `<sheetViews><sheetView workbookViewId="0">`+
+			`<pane ySplit="%d" topLeftCell="A%d" activePane="bottomLeft" state="frozen"/>`+
+			`</sheetView></sheetViews>`, head, head+1)
+	}
+	if len(m.Columns) > 0 {
+		b.WriteString("<cols>")
+		for j, c := range m.Columns {
+			fmt.Fprintf(&b, `<col min="%d" max="%[1]d" width="%d" customWidth="1"/>`, j+1, c.Width+2)
+		}
+		b.WriteString("</cols>")
+	}
+	b.WriteString("<sheetData>")
+	var merged []string
+	rows := m.rows()
+	for i, cells := range rows {
+		fmt.Fprintf(&b, `<row r="%d">`, i+1)
+		for j, c := range cells {
+			col := columnName(j)
+			ref := col + strconv.Itoa(i+1)
+			isHead, isFoot := i < head, i >= head+body
+			if !c.Merged && (c.Rows > 1 || c.Cols > 1) {
+				merged = append(merged, fmt.Sprintf("%s:%s%d", ref, columnName(j+c.Cols-1), i+c.Rows))
+			}
+			if c.Merged || c.Text == "" {
+				if isHead {
+					fmt.Fprintf(&b, `<c r="%s" s="%d"/>`, ref, xlsxHead)
+				}
+				continue
+			}
+			kind, t := sheetValue(c.Text)
+			if isHead || c.Link != "" {
+				kind = sheetString
+			}
+			s := ""
+			if style := xlsxStyle(kind, isHead, isFoot); style != xlsxPlain {
+				s = fmt.Sprintf(` s="%d"`, style)
+			}
+			switch {
+			case c.Link != "":
+				f := fmt.Sprintf("HYPERLINK(%s,%s)", xlsxString(c.Link), xlsxString(c.Text))
+				fmt.Fprintf(&b, `<c r="%s"%s t="str"><f>%s</f><v>%s</v></c>`, ref, s, xmlEscape(f), xmlEscape(c.Text))
+			case kind == sheetNumber:
+				var f string
+				if isFoot {
+					if a := sheetFormula(m, j, c.Text, aggregates); a != "" {
+						f = fmt.Sprintf("<f>%s(%s%d:%s%d)</f>", a, col, head+1, col, head+body)
+					}
+				}
+				fmt.Fprintf(&b, `<c r="%s"%s>%s<v>%s</v></c>`, ref, s, f, c.Text)
+			case kind == sheetDate || kind == sheetDateTime:
+				fmt.Fprintf(&b, `<c r="%s"%s><v>%s</v></c>`, ref, s, strconv.FormatFloat(excelSerial(t), 'f', -1, 64))
+			default:
+				fmt.Fprintf(&b, `<c r="%s"%s t="inlineStr">%s</c>`, ref, s, xlsxInline(c.Text))
+			}
+		}
+		b.WriteString("</row>")
+	}
+	if m.Caption != "" {
+		fmt.Fprintf(&b, `<row r="%d"><c r="A%[1]d" t="inlineStr">%s</c></row>`, len(rows)+2, xlsxInline(m.Caption))
+	}
+	b.WriteString("</sheetData>")
+	if len(merged) > 0 {
+		fmt.Fprintf(&b, `<mergeCells count="%d">`, len(merged))
+		for _, ref := range merged {
+			fmt.Fprintf(&b, `<mergeCell ref="%s"/>`, ref)
+		}
+		b.WriteString("</mergeCells>")
+	}
+	b.WriteString("</worksheet>")
+	return b.String()
+}
+
+// xlsxInline returns s as inline string of a cell.
+func xlsxInline(s string) string {
+	return `<is><t xml:space="preserve">` + xmlEscape(s) + `</t></is>`
+}
+
+// xlsxString returns s as string literal of a formula.
+func xlsxString(s string) string {
+	return `"` + strings.Replace(s, `"`, `""`, -1) + `"`
+}
+
+const xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+	`</Types>`
+
+const xlsxRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbook = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+const xlsxWorkbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+// xlsxStyles has the cellXfs xlsxPlain, xlsxHead, xlsxDate, xlsxDateTime
+// and xlsxFoot, in this order.
+const xlsxStyles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="2"><numFmt numFmtId="164" formatCode="yyyy-mm-dd"/><numFmt numFmtId="165" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill>` +
+	`<fill><patternFill patternType="solid"><fgColor rgb="FFD9D9D9"/><bgColor indexed="64"/></patternFill></fill></fills>` +
+	`<borders count="2"><border><left/><right/><top/><bottom/><diagonal/></border>` +
+	`<border><left/><right/><top/><bottom style="thin"><color auto="1"/></bottom><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="5">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="2" borderId="1" xfId="0" applyFont="1" applyFill="1" applyBorder="1"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="165" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
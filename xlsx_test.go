@@ -0,0 +1,114 @@
+package table
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// newSheetTable returns a table with a date column and a footer row.
+func newSheetTable(r Renderer) *Table {
+	ta, _ := New(true, [][]string{
+		{"Name", "When", "Qty", "Price"},
+		{"a&b", "2024-01-02", "3", "1.5"},
+		{"c", "2024-02-03 12:00:00", "4", "2.25"},
+		{"Total", "", "7", "1.88"}}...)
+	ta.FooterRows = 1
+	ta.Title = "Q1: sales"
+	ta.Link(1, 0, "http://x")
+	ta.Renderer = r
+	return ta
+}
+
+// readZip returns the files of the zip archive b.
+func readZip(t *testing.T, b []byte) ([]string, map[string]string) {
+	z, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	files := make(map[string]string)
+	for _, f := range z.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, f.Name)
+		files[f.Name] = string(data)
+	}
+	return names, files
+}
+
+func TestXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := newSheetTable(XLSX{}).WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	_, files := readZip(t, buf.Bytes())
+	if !strings.Contains(files["xl/workbook.xml"], `<sheet name="Q1 sales"`) {
+		t.Errorf("wrong sheet name:\n%s", files["xl/workbook.xml"])
+	}
+	sheet := files["xl/worksheets/sheet1.xml"]
+	for _, exp := range []string{
+		`<pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/>`,
+		`<col min="1" max="1" width="7" customWidth="1"/><col min="2" max="2" width="21" customWidth="1"/>`,
+		`<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">Name</t></is></c>`,
+		`<c r="A2" t="str"><f>HYPERLINK(&#34;http://x&#34;,&#34;a&amp;b&#34;)</f><v>a&amp;b</v></c>`,
+		`<c r="B2" s="2"><v>45293</v></c><c r="C2"><v>3</v></c>`,
+		`<c r="B3" s="3"><v>45325.5</v></c>`,
+		`<c r="A4" s="4" t="inlineStr">`,
+		`<c r="C4" s="4"><f>SUM(C2:C3)</f><v>7</v></c><c r="D4" s="4"><v>1.88</v></c>`,
+	} {
+		if !strings.Contains(sheet, exp) {
+			t.Errorf("sheet should contain %s:\n%s", exp, sheet)
+		}
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/_rels/workbook.xml.rels", "xl/styles.xml"} {
+		if files[name] == "" {
+			t.Errorf("missing %s", name)
+		}
+	}
+}
+
+func TestXLSX_aggregates(t *testing.T) {
+	m, _ := newSheetTable(nil).Model()
+	sheet := xlsxSheet(m, []string{"", "", "", "average"})
+	exp := `<c r="C4" s="4"><v>7</v></c><c r="D4" s="4"><f>AVERAGE(D2:D3)</f><v>1.88</v></c>`
+	if !strings.Contains(sheet, exp) {
+		t.Errorf("sheet should contain %s:\n%s", exp, sheet)
+	}
+	var buf bytes.Buffer
+	if err := (XLSX{Aggregates: []string{"MEDIAN"}}).Render(&buf, m); err == nil {
+		t.Error("an unknown function should fail")
+	}
+	m.Body = nil
+	if sheet := xlsxSheet(m, []string{"", "", "sum"}); strings.Contains(sheet, "<f>") {
+		t.Errorf("a footer without body rows should have no formula:\n%s", sheet)
+	}
+	if content := odsContent(m, "s", []string{"", "", "sum"}); strings.Contains(content, "table:formula") {
+		t.Errorf("a footer without body rows should have no formula:\n%s", content)
+	}
+}
+
+func TestXLSX_spans(t *testing.T) {
+	ta, _ := New(false, [][]string{{"a", "b"}, {"c", "d"}}...)
+	ta.Span(0, 0, 2, 1)
+	ta.Caption = "x"
+	m, _ := ta.Model()
+	sheet := xlsxSheet(m, nil)
+	for _, exp := range []string{`<row r="2"><c r="B2" t="inlineStr">`, `<mergeCells count="1"><mergeCell ref="A1:A2"/></mergeCells>`,
+		`<row r="4"><c r="A4" t="inlineStr"><is><t xml:space="preserve">x</t></is></c></row>`} {
+		if !strings.Contains(sheet, exp) {
+			t.Errorf("sheet should contain %s:\n%s", exp, sheet)
+		}
+	}
+	if strings.Contains(sheet, "sheetViews") {
+		t.Errorf("a table without header should not freeze rows:\n%s", sheet)
+	}
+}
@@ -0,0 +1,78 @@
+package table
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// YAML renders the body rows of the model as sequence of mappings keyed
+// by the column names, or as sequence of sequences if the model has no
+// header. Cells which are numbers or booleans are written as such,
+// unless Strings is true.
+type YAML struct {
+	Columns []string // names of the written columns in this order, all if empty
+	Strings bool     // if true, all cells are written as strings
+}
+
+// Render the model m.
+func (y YAML) Render(w io.Writer, m *Model) error {
+	cols, err := project(m, y.Columns)
+	if err != nil {
+		return err
+	}
+	var b bytes.Buffer
+	if len(m.Body) == 0 {
+		b.WriteString("[]\n")
+	}
+	for _, cells := range m.Body {
+		if len(cols) == 0 {
+			b.WriteString("- []\n")
+			continue
+		}
+		for k, c := range cols {
+			if k == 0 {
+				b.WriteString("- ")
+			} else {
+				b.WriteString("  ")
+			}
+			if len(m.Header) == 0 {
+				b.WriteString("- ")
+			} else {
+				b.WriteString(yamlString(m.Columns[c].Name) + ": ")
+			}
+			b.WriteString(yamlValue(cells[c].Text, y.Strings) + "\n")
+		}
+	}
+	_, err = w.Write(b.Bytes())
+	return err
+}
+
+// yamlPlain matches strings, which can be written without quotes.
+var yamlPlain = regexp.MustCompile(`^[\pL_][\pL\pN_ ./()-]*$`)
+
+// yamlReserved are plain strings YAML 1.1 parsers read as other types.
+var yamlReserved = map[string]bool{
+	"true": true, "false": true, "yes": true, "no": true, "on": true,
+	"off": true, "y": true, "n": true, "null": true,
+}
+
+// yamlValue returns s as number or boolean if it is one and strings is
+// false, otherwise as string.
+func yamlValue(s string, strings bool) string {
+	if !strings && isTyped(s) {
+		return s
+	}
+	return yamlString(s)
+}
+
+// yamlString returns s plain if possible, otherwise double quoted.
+// YAML double quoted strings are a superset of JSON strings.
+func yamlString(s string) string {
+	if yamlPlain.MatchString(s) && !strings.HasSuffix(s, " ") &&
+		!yamlReserved[strings.ToLower(s)] {
+		return s
+	}
+	return string(jsonString(s))
+}
@@ -0,0 +1,50 @@
+package table
+
+import "testing"
+
+func TestYAML(t *testing.T) {
+	ta := newModelTable()
+	ta.Renderer = YAML{}
+	exp := "- Name: a\n  Latency p50: 1\n  Latency p99: 95\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestYAML_noHeader(t *testing.T) {
+	ta, _ := New(false, [][]string{{"a", "yes"}, {"b: c", "2"}}...)
+	ta.Renderer = YAML{Strings: true}
+	exp := "- - a\n  - \"yes\"\n- - \"b: c\"\n  - \"2\"\n"
+	if s := ta.String(); s != exp {
+		t.Errorf("\n\nshould be:\n%q\n\nbut is:\n%q", exp, s)
+	}
+}
+
+func TestYAML_empty(t *testing.T) {
+	ta, _ := New(true, [][]string{{"a"}}...)
+	ta.Renderer = YAML{}
+	if s := ta.String(); s != "[]\n" {
+		t.Errorf("should be %q but is %q", "[]\n", s)
+	}
+}
+
+func TestYAMLString(t *testing.T) {
+	tests := map[string]string{
+		"a b":   "a b",
+		"":      `""`,
+		"Null":  `"Null"`,
+		"a ":    `"a "`,
+		"-a":    `"-a"`,
+		"a\nb":  `"a\nb"`,
+		"über":  "über",
+		"#x":    `"#x"`,
+		"a: b":  `"a: b"`,
+		"v1.2":  "v1.2",
+		"(a)-b": `"(a)-b"`,
+	}
+	for in, exp := range tests {
+		if s := yamlString(in); s != exp {
+			t.Errorf("%q should be %s but is %s", in, exp, s)
+		}
+	}
+}